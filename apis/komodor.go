@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	realtimemonitorv1alpha1 "github.com/crossplane/provider-komodor/apis/komodor/v1alpha1"
+	realtimemonitorv1beta1 "github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
 	komodorv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
 )
 
@@ -29,6 +30,7 @@ func init() {
 	AddToSchemes = append(AddToSchemes,
 		komodorv1alpha1.SchemeBuilder.AddToScheme,
 		realtimemonitorv1alpha1.SchemeBuilder.AddToScheme,
+		realtimemonitorv1beta1.SchemeBuilder.AddToScheme,
 	)
 }
 
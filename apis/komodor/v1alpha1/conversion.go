@@ -0,0 +1,424 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+)
+
+// AnnotationKeyConversionData holds the v1beta1 fields v1alpha1 cannot
+// represent, so they survive reading and writing a RealtimeMonitor as
+// v1alpha1.
+const AnnotationKeyConversionData = Group + "/conversion-data"
+
+// conversionData are the v1beta1 fields without a v1alpha1 equivalent.
+type conversionData struct {
+	AdoptionPolicy        v1beta1.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+	DeletionMode          v1beta1.DeletionMode   `json:"deletionMode,omitempty"`
+	DeactivatedNameSuffix string                 `json:"deactivatedNameSuffix,omitempty"`
+	NotificationChannels  []string               `json:"notificationChannels,omitempty"`
+	SinkRefs              []xpv1.Reference       `json:"sinkRefs,omitempty"`
+	SinkSelector          *xpv1.Selector         `json:"sinkSelector,omitempty"`
+
+	// Legacy holds the v1alpha1 fields v1beta1 cannot represent. It is
+	// kept on the v1beta1 object.
+	Legacy *legacyParameters `json:"legacy,omitempty"`
+}
+
+// legacyParameters are v1alpha1 parameters as they were written, kept when
+// their v1beta1 form loses part of them.
+type legacyParameters struct {
+	Sensors   []apiextensionsv1.JSON `json:"sensors,omitempty"`
+	Sinks     *apiextensionsv1.JSON  `json:"sinks,omitempty"`
+	Type      string                 `json:"type,omitempty"`
+	Variables *apiextensionsv1.JSON  `json:"variables,omitempty"`
+}
+
+// ConvertTo converts this RealtimeMonitor to the v1beta1 hub version. The raw
+// sensors, sinks and variables are decoded into their typed form and the
+// variables moved under the field of the monitor's type. Fields the typed
+// form does not know, values it cannot decode, a type v1beta1 does not know
+// and variables that do not apply to the type are kept in the conversion
+// data annotation of the hub version.
+func (src *RealtimeMonitor) ConvertTo(hub conversion.Hub) error {
+	dst, ok := hub.(*v1beta1.RealtimeMonitor)
+	if !ok {
+		return errors.Errorf("cannot convert %s to %T", RealtimeMonitorKindAPIVersion, hub)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec.ResourceSpec = src.Spec.ResourceSpec
+	dst.Status.ResourceStatus = src.Status.ResourceStatus
+
+	var data conversionData
+	if raw, ok := dst.Annotations[AnnotationKeyConversionData]; ok {
+		if err := json.Unmarshal([]byte(raw), &data); err != nil {
+			return errors.Wrapf(err, "cannot decode %s annotation", AnnotationKeyConversionData)
+		}
+		dst.Spec.AdoptionPolicy = data.AdoptionPolicy
+		dst.Spec.DeletionMode = data.DeletionMode
+		dst.Spec.DeactivatedNameSuffix = data.DeactivatedNameSuffix
+		delete(dst.Annotations, AnnotationKeyConversionData)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	p := src.Spec.ForProvider
+	dst.Spec.ForProvider = v1beta1.RealtimeMonitorParameters{
+		Name:                 p.Name,
+		Active:               p.Active,
+		SinksOptions:         p.SinksOptions,
+		NotificationChannels: data.NotificationChannels,
+		SinkRefs:             data.SinkRefs,
+		SinkSelector:         data.SinkSelector,
+	}
+	// Values that cannot be decoded are left unset; they are kept as legacy
+	// data below.
+	if err := fromRawList(p.Sensors, &dst.Spec.ForProvider.Sensors); err != nil {
+		dst.Spec.ForProvider.Sensors = nil
+	}
+	if err := fromRaw(p.Sinks, &dst.Spec.ForProvider.Sinks); err != nil {
+		dst.Spec.ForProvider.Sinks = v1beta1.Sinks{}
+	}
+	var variables *v1beta1.Variables
+	if err := fromRawPtr(p.Variables, &variables); err != nil {
+		variables = nil
+	}
+	// A type v1beta1 does not know leaves the monitor without one; it is kept
+	// as legacy data below.
+	dst.Spec.ForProvider.MonitorTypeParameters, _ = v1beta1.NewMonitorTypeParameters(p.Type, variables)
+
+	legacy, err := legacyParametersOf(&p, &dst.Spec.ForProvider)
+	if err != nil {
+		return errors.Wrap(err, "cannot convert spec.forProvider")
+	}
+	if legacy != nil {
+		b, err := json.Marshal(conversionData{Legacy: legacy})
+		if err != nil {
+			return errors.Wrapf(err, "cannot encode %s annotation", AnnotationKeyConversionData)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[AnnotationKeyConversionData] = string(b)
+	}
+
+	o := src.Status.AtProvider
+	dst.Status.AtProvider = v1beta1.RealtimeMonitorObservation{
+		ID:           o.ID,
+		CreatedAt:    o.CreatedAt,
+		UpdatedAt:    o.UpdatedAt,
+		IsDeleted:    o.IsDeleted,
+		Name:         o.Name,
+		Active:       o.Active,
+		Type:         o.Type,
+		SinksOptions: o.SinksOptions,
+	}
+	if err := fromRawList(o.Sensors, &dst.Status.AtProvider.Sensors); err != nil {
+		return errors.Wrap(err, "cannot convert status.atProvider.sensors")
+	}
+	if err := fromRawPtr(o.Sinks, &dst.Status.AtProvider.Sinks); err != nil {
+		return errors.Wrap(err, "cannot convert status.atProvider.sinks")
+	}
+	if err := fromRawPtr(o.Variables, &dst.Status.AtProvider.Variables); err != nil {
+		return errors.Wrap(err, "cannot convert status.atProvider.variables")
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1beta1 hub version to this RealtimeMonitor. Fields
+// without a v1alpha1 equivalent are kept in the conversion data annotation.
+func (dst *RealtimeMonitor) ConvertFrom(hub conversion.Hub) error {
+	src, ok := hub.(*v1beta1.RealtimeMonitor)
+	if !ok {
+		return errors.Errorf("cannot convert %T to %s", hub, RealtimeMonitorKindAPIVersion)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec.ResourceSpec = src.Spec.ResourceSpec
+	dst.Status.ResourceStatus = src.Status.ResourceStatus
+
+	var legacy *legacyParameters
+	if raw, ok := dst.Annotations[AnnotationKeyConversionData]; ok {
+		var hubData conversionData
+		if err := json.Unmarshal([]byte(raw), &hubData); err != nil {
+			return errors.Wrapf(err, "cannot decode %s annotation", AnnotationKeyConversionData)
+		}
+		legacy = hubData.Legacy
+		delete(dst.Annotations, AnnotationKeyConversionData)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	data := conversionData{
+		AdoptionPolicy:        src.Spec.AdoptionPolicy,
+		DeletionMode:          src.Spec.DeletionMode,
+		DeactivatedNameSuffix: src.Spec.DeactivatedNameSuffix,
+		NotificationChannels:  src.Spec.ForProvider.NotificationChannels,
+		SinkRefs:              src.Spec.ForProvider.SinkRefs,
+		SinkSelector:          src.Spec.ForProvider.SinkSelector,
+	}
+	if !reflect.DeepEqual(data, conversionData{}) {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return errors.Wrapf(err, "cannot encode %s annotation", AnnotationKeyConversionData)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[AnnotationKeyConversionData] = string(b)
+	}
+
+	p := src.Spec.ForProvider
+	dst.Spec.ForProvider = RealtimeMonitorParameters{
+		Name:         p.Name,
+		Active:       p.Active,
+		Type:         p.Type(),
+		SinksOptions: p.SinksOptions,
+	}
+	var err error
+	if dst.Spec.ForProvider.Sensors, err = toRawList(p.Sensors); err != nil {
+		return errors.Wrap(err, "cannot convert spec.forProvider.sensors")
+	}
+	if dst.Spec.ForProvider.Sinks, err = toRaw(&p.Sinks); err != nil {
+		return errors.Wrap(err, "cannot convert spec.forProvider.sinks")
+	}
+	if dst.Spec.ForProvider.Variables, err = toRaw(p.Variables()); err != nil {
+		return errors.Wrap(err, "cannot convert spec.forProvider.variables")
+	}
+	if legacy != nil {
+		restoreLegacyParameters(&dst.Spec.ForProvider, legacy, &p)
+	}
+
+	o := src.Status.AtProvider
+	dst.Status.AtProvider = RealtimeMonitorObservation{
+		ID:           o.ID,
+		CreatedAt:    o.CreatedAt,
+		UpdatedAt:    o.UpdatedAt,
+		IsDeleted:    o.IsDeleted,
+		Name:         o.Name,
+		Active:       o.Active,
+		Type:         o.Type,
+		SinksOptions: o.SinksOptions,
+	}
+	if dst.Status.AtProvider.Sensors, err = toRawList(o.Sensors); err != nil {
+		return errors.Wrap(err, "cannot convert status.atProvider.sensors")
+	}
+	if dst.Status.AtProvider.Sinks, err = toRaw(o.Sinks); err != nil {
+		return errors.Wrap(err, "cannot convert status.atProvider.sinks")
+	}
+	if dst.Status.AtProvider.Variables, err = toRaw(o.Variables); err != nil {
+		return errors.Wrap(err, "cannot convert status.atProvider.variables")
+	}
+	return nil
+}
+
+// legacyParametersOf returns the parameters of src that their v1beta1 form
+// dst does not represent as written, or nil if dst represents all of them.
+func legacyParametersOf(src *RealtimeMonitorParameters, dst *v1beta1.RealtimeMonitorParameters) (*legacyParameters, error) {
+	l := &legacyParameters{}
+	sensors, err := toRawList(dst.Sensors)
+	if err != nil {
+		return nil, err
+	}
+	if len(sensors) != len(src.Sensors) {
+		l.Sensors = src.Sensors
+	}
+	for i := 0; l.Sensors == nil && i < len(sensors); i++ {
+		if !equalJSON(src.Sensors[i], sensors[i]) {
+			l.Sensors = src.Sensors
+		}
+	}
+	sinks, err := toRaw(&dst.Sinks)
+	if err != nil {
+		return nil, err
+	}
+	if !equalJSON(src.Sinks, sinks) {
+		l.Sinks = src.Sinks.DeepCopy()
+	}
+	if dst.Type() != src.Type {
+		l.Type = src.Type
+	}
+	variables, err := toRaw(dst.Variables())
+	if err != nil {
+		return nil, err
+	}
+	if !equalJSON(src.Variables, variables) {
+		l.Variables = src.Variables.DeepCopy()
+	}
+	if reflect.DeepEqual(l, &legacyParameters{}) {
+		return nil, nil
+	}
+	return l, nil
+}
+
+// restoreLegacyParameters replaces the parameters of dst with their legacy
+// form, as long as the v1beta1 parameters src they were converted from are
+// unchanged since the legacy form was kept.
+func restoreLegacyParameters(dst *RealtimeMonitorParameters, l *legacyParameters, src *v1beta1.RealtimeMonitorParameters) {
+	if l.Sensors != nil {
+		var sensors []v1beta1.Sensor
+		if err := fromRawList(l.Sensors, &sensors); err != nil {
+			sensors = nil
+		}
+		if sameJSON(sensors, src.Sensors) {
+			dst.Sensors = l.Sensors
+		}
+	}
+	if l.Sinks != nil {
+		var sinks v1beta1.Sinks
+		if err := fromRaw(*l.Sinks, &sinks); err != nil {
+			sinks = v1beta1.Sinks{}
+		}
+		if sameJSON(&sinks, &src.Sinks) {
+			dst.Sinks = *l.Sinks
+		}
+	}
+	if l.Type == "" && l.Variables == nil {
+		return
+	}
+	monitorType, variables := dst.Type, dst.Variables
+	if l.Type != "" {
+		monitorType = l.Type
+	}
+	if l.Variables != nil {
+		variables = *l.Variables
+	}
+	var v *v1beta1.Variables
+	if err := fromRawPtr(variables, &v); err != nil {
+		v = nil
+	}
+	mt, _ := v1beta1.NewMonitorTypeParameters(monitorType, v)
+	if !sameJSON(&mt, &src.MonitorTypeParameters) {
+		return
+	}
+	dst.Type, dst.Variables = monitorType, variables
+}
+
+// sameJSON returns true if a and b encode to the same JSON value.
+func sameJSON(a, b any) bool {
+	ja, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	jb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return equalJSON(apiextensionsv1.JSON{Raw: ja}, apiextensionsv1.JSON{Raw: jb})
+}
+
+// equalJSON returns true if a and b encode the same value. Unset JSON, null
+// and empty objects or lists are all equal.
+func equalJSON(a, b apiextensionsv1.JSON) bool {
+	decode := func(j apiextensionsv1.JSON) (any, bool) {
+		var v any
+		if len(j.Raw) == 0 {
+			return nil, true
+		}
+		if err := json.Unmarshal(j.Raw, &v); err != nil {
+			return nil, false
+		}
+		switch t := v.(type) {
+		case map[string]any:
+			if len(t) == 0 {
+				return nil, true
+			}
+		case []any:
+			if len(t) == 0 {
+				return nil, true
+			}
+		}
+		return v, true
+	}
+	av, aok := decode(a)
+	bv, bok := decode(b)
+	return aok && bok && reflect.DeepEqual(av, bv)
+}
+
+// fromRaw decodes j into out, leaving out untouched if j is unset.
+func fromRaw[T any](j apiextensionsv1.JSON, out *T) error {
+	if len(j.Raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(j.Raw, out)
+}
+
+// fromRawPtr decodes j into a new value stored in out, leaving out nil if j
+// is unset.
+func fromRawPtr[T any](j apiextensionsv1.JSON, out **T) error {
+	if len(j.Raw) == 0 || string(j.Raw) == "null" {
+		return nil
+	}
+	v := new(T)
+	if err := json.Unmarshal(j.Raw, v); err != nil {
+		return err
+	}
+	*out = v
+	return nil
+}
+
+// fromRawList decodes each element of js into out.
+func fromRawList[T any](js []apiextensionsv1.JSON, out *[]T) error {
+	if js == nil {
+		return nil
+	}
+	l := make([]T, len(js))
+	for i := range js {
+		if err := json.Unmarshal(js[i].Raw, &l[i]); err != nil {
+			return errors.Wrapf(err, "index %d", i)
+		}
+	}
+	*out = l
+	return nil
+}
+
+// toRaw encodes v, returning unset JSON if v is nil.
+func toRaw[T any](v *T) (apiextensionsv1.JSON, error) {
+	if v == nil {
+		return apiextensionsv1.JSON{}, nil
+	}
+	b, err := json.Marshal(v)
+	return apiextensionsv1.JSON{Raw: b}, err
+}
+
+// toRawList encodes each element of l.
+func toRawList[T any](l []T) ([]apiextensionsv1.JSON, error) {
+	if l == nil {
+		return nil, nil
+	}
+	js := make([]apiextensionsv1.JSON, len(l))
+	for i := range l {
+		b, err := json.Marshal(l[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "index %d", i)
+		}
+		js[i] = apiextensionsv1.JSON{Raw: b}
+	}
+	return js, nil
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+)
+
+func TestConvertTo(t *testing.T) {
+	raw := func(s string) apiextensionsv1.JSON { return apiextensionsv1.JSON{Raw: []byte(s)} }
+
+	cases := map[string]struct {
+		reason string
+		src    *RealtimeMonitor
+		want   *v1beta1.RealtimeMonitor
+		err    bool
+	}{
+		"Typed": {
+			reason: "Raw sensors, sinks and variables should decode into their typed form, keeping unknown fields and variables of other types as legacy data.",
+			src: &RealtimeMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "m"},
+				Spec: RealtimeMonitorSpec{ForProvider: RealtimeMonitorParameters{
+					Name:      "monitor",
+					Active:    true,
+					Type:      "availability",
+					Sensors:   []apiextensionsv1.JSON{raw(`{"cluster":"prod","exclude":{},"labels":["app:web"],"unknown":1}`)},
+					Sinks:     raw(`{"slack":["alerts"]}`),
+					Variables: raw(`{"duration":300,"minAvailable":"85%","cronJobCondition":"any"}`),
+				}},
+			},
+			want: &v1beta1.RealtimeMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "m", Annotations: map[string]string{
+					AnnotationKeyConversionData: `{"legacy":{"sensors":[{"cluster":"prod","exclude":{},"labels":["app:web"],"unknown":1}],"variables":{"duration":300,"minAvailable":"85%","cronJobCondition":"any"}}}`,
+				}},
+				Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{
+					Name:    "monitor",
+					Active:  true,
+					Sensors: []v1beta1.Sensor{{Cluster: "prod", Exclude: &v1beta1.SensorScope{}, Labels: []string{"app:web"}}},
+					Sinks:   v1beta1.Sinks{Slack: []string{"alerts"}},
+					MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+						Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(300), MinAvailable: ptr.To(intstr.FromString("85%"))},
+					},
+				}},
+			},
+		},
+		"WrongType": {
+			reason: "A raw value of the wrong type should be left unset and kept as legacy data.",
+			src: &RealtimeMonitor{
+				Spec: RealtimeMonitorSpec{ForProvider: RealtimeMonitorParameters{
+					Type:    "job",
+					Sensors: []apiextensionsv1.JSON{raw(`{"cluster":["prod"]}`)},
+				}},
+			},
+			want: &v1beta1.RealtimeMonitor{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					AnnotationKeyConversionData: `{"legacy":{"sensors":[{"cluster":["prod"]}]}}`,
+				}},
+				Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{
+					MonitorTypeParameters: v1beta1.MonitorTypeParameters{Job: &v1beta1.JobMonitor{}},
+				}},
+			},
+		},
+		"UnknownMonitorType": {
+			reason: "A monitor type v1beta1 does not know should leave the monitor without a type and be kept with its variables as legacy data.",
+			src: &RealtimeMonitor{
+				Spec: RealtimeMonitorSpec{ForProvider: RealtimeMonitorParameters{
+					Type:      "bar",
+					Variables: raw(`{"duration":60}`),
+				}},
+			},
+			want: &v1beta1.RealtimeMonitor{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+					AnnotationKeyConversionData: `{"legacy":{"type":"bar","variables":{"duration":60}}}`,
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &v1beta1.RealtimeMonitor{}
+			err := tc.src.ConvertTo(got)
+			if (err != nil) != tc.err {
+				t.Fatalf("\n%s\nConvertTo(...): want error %t, got %v", tc.reason, tc.err, err)
+			}
+			if tc.err {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConvertTo(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	hub := &v1beta1.RealtimeMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "m"},
+		Spec: v1beta1.RealtimeMonitorSpec{
+			AdoptionPolicy:        v1beta1.AdoptionPolicyByName,
+			DeletionMode:          v1beta1.DeletionModeDeactivate,
+			DeactivatedNameSuffix: " (orphaned)",
+			ForProvider: v1beta1.RealtimeMonitorParameters{
+				Name:    "monitor",
+				Active:  true,
+				Sensors: []v1beta1.Sensor{{Cluster: "prod", Conditions: []string{"Ready"}}},
+				MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+					Node: &v1beta1.NodeMonitor{Duration: ptr.To(60), NodeCreationThreshold: "5m"},
+				},
+				Sinks:                v1beta1.Sinks{PagerDuty: []v1beta1.PagerDutySink{{Channel: "c", IntegrationKey: "k"}}},
+				SinksOptions:         map[string][]string{"notifyOn": {"NotReady"}},
+				NotificationChannels: []string{"oncall"},
+				SinkRefs:             []xpv1.Reference{{Name: "oncall"}},
+			},
+		},
+		Status: v1beta1.RealtimeMonitorStatus{AtProvider: v1beta1.RealtimeMonitorObservation{
+			ID:      "id",
+			Sensors: []v1beta1.SensorObservation{{Cluster: "prod"}},
+			Sinks:   &v1beta1.Sinks{Teams: []string{"ops"}},
+		}},
+	}
+
+	spoke := &RealtimeMonitor{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom(...): %v", err)
+	}
+	got := &v1beta1.RealtimeMonitor{}
+	if err := spoke.ConvertTo(got); err != nil {
+		t.Fatalf("ConvertTo(...): %v", err)
+	}
+	if diff := cmp.Diff(hub, got); diff != "" {
+		t.Errorf("ConvertTo(ConvertFrom(...)): -want, +got:\n%s", diff)
+	}
+}
+
+func TestConvertLegacyRoundTrip(t *testing.T) {
+	raw := func(s string) apiextensionsv1.JSON { return apiextensionsv1.JSON{Raw: []byte(s)} }
+
+	cases := map[string]struct {
+		reason string
+		spoke  *RealtimeMonitor
+	}{
+		"UnknownMonitorType": {
+			reason: "A monitor type v1beta1 does not know should survive a round trip through v1beta1.",
+			spoke: &RealtimeMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "m"},
+				Spec: RealtimeMonitorSpec{ForProvider: RealtimeMonitorParameters{
+					Name:      "monitor",
+					Active:    true,
+					Type:      "bar",
+					Sinks:     raw(`{"slack":["alerts"]}`),
+					Variables: raw(`{"duration":60,"custom":true}`),
+				}},
+			},
+		},
+		"UnknownFields": {
+			reason: "Sensor and sink fields v1beta1 does not know should survive a round trip through v1beta1.",
+			spoke: &RealtimeMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "m"},
+				Spec: RealtimeMonitorSpec{ForProvider: RealtimeMonitorParameters{
+					Name:    "monitor",
+					Active:  true,
+					Type:    "job",
+					Sensors: []apiextensionsv1.JSON{raw(`{"cluster":"prod","priority":"high"}`), raw(`{"cluster":"staging"}`)},
+					Sinks:   raw(`{"slack":["alerts"],"email":["oncall@example.org"]}`),
+				}},
+			},
+		},
+		"VariablesOfOtherTypes": {
+			reason: "Variables that do not apply to the monitor type should survive a round trip through v1beta1.",
+			spoke: &RealtimeMonitor{
+				ObjectMeta: metav1.ObjectMeta{Name: "m"},
+				Spec: RealtimeMonitorSpec{ForProvider: RealtimeMonitorParameters{
+					Name:      "monitor",
+					Active:    true,
+					Type:      "availability",
+					Sinks:     raw(`{"slack":["alerts"]}`),
+					Variables: raw(`{"duration":300,"cronJobCondition":"any"}`),
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hub := &v1beta1.RealtimeMonitor{}
+			if err := tc.spoke.ConvertTo(hub); err != nil {
+				t.Fatalf("\n%s\nConvertTo(...): %v", tc.reason, err)
+			}
+			got := &RealtimeMonitor{}
+			if err := got.ConvertFrom(hub); err != nil {
+				t.Fatalf("\n%s\nConvertFrom(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.spoke, got); diff != "" {
+				t.Errorf("\n%s\nConvertFrom(ConvertTo(...)): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConvertFromStaleLegacy(t *testing.T) {
+	reason := "Legacy data of a monitor changed since it was kept should be ignored."
+	hub := &v1beta1.RealtimeMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "m", Annotations: map[string]string{
+			AnnotationKeyConversionData: `{"legacy":{"type":"bar","variables":{"duration":60}}}`,
+		}},
+		Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{
+			Name: "monitor",
+			MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+				Job: &v1beta1.JobMonitor{},
+			},
+		}},
+	}
+
+	got := &RealtimeMonitor{}
+	if err := got.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom(...): %v", err)
+	}
+	want := &RealtimeMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "m"},
+		Spec: RealtimeMonitorSpec{ForProvider: RealtimeMonitorParameters{
+			Name:  "monitor",
+			Type:  "job",
+			Sinks: apiextensionsv1.JSON{Raw: []byte(`{}`)},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("\n%s\nConvertFrom(...): -want, +got:\n%s", reason, diff)
+	}
+}
@@ -86,6 +86,7 @@ type RealtimeMonitorStatus struct {
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:deprecatedversion:warning="komodor.komodor.crossplane.io/v1alpha1 RealtimeMonitor is deprecated, use v1beta1"
 
 // A RealtimeMonitor is an example API type.
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+func TestClusterName(t *testing.T) {
+	observed := func(c xpv1.Condition) *Cluster {
+		cr := &Cluster{Status: ClusterStatus{AtProvider: ClusterObservation{ID: "c-1", Name: "prod"}}}
+		cr.SetConditions(c)
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   string
+	}{
+		"Ready": {
+			reason: "A ready Cluster should resolve to its Komodor name.",
+			mg:     observed(xpv1.Available()),
+			want:   "prod",
+		},
+		"NotReady": {
+			reason: "A Cluster that is not ready should not resolve.",
+			mg:     observed(xpv1.Unavailable()),
+			want:   "",
+		},
+		"NotACluster": {
+			reason: "Other managed resources should not resolve.",
+			mg:     &RealtimeMonitor{},
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ClusterName()(tc.mg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nClusterName()(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
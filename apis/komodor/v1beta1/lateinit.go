@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import "reflect"
+
+// LateInitialize fills the fields p leaves unset with those of from and
+// reports whether any field was filled. Variables are filled if both select
+// the same monitor type, sensor fields if both have a single sensor for the
+// same cluster, and sink options by key. Names, sinks and notification
+// channels are never filled.
+func (p *RealtimeMonitorParameters) LateInitialize(from *RealtimeMonitorParameters) bool {
+	if from == nil {
+		return false
+	}
+	filled := p.MonitorTypeParameters.lateInitialize(&from.MonitorTypeParameters)
+	for i := range p.Sensors {
+		if f := singleSensor(from.Sensors, p.Sensors[i].Cluster); f != nil && singleSensor(p.Sensors, p.Sensors[i].Cluster) != nil {
+			filled = p.Sensors[i].lateInitialize(f) || filled
+		}
+	}
+	for k, v := range from.SinksOptions {
+		if _, ok := p.SinksOptions[k]; ok {
+			continue
+		}
+		if p.SinksOptions == nil {
+			p.SinksOptions = make(map[string][]string, len(from.SinksOptions))
+		}
+		p.SinksOptions[k] = append([]string(nil), v...)
+		filled = true
+	}
+	return filled
+}
+
+func (p *MonitorTypeParameters) lateInitialize(from *MonitorTypeParameters) bool {
+	if p.Type() != from.Type() {
+		return false
+	}
+	v, f := p.Variables(), from.DeepCopy().Variables()
+	if v == nil || f == nil {
+		return false
+	}
+	if v.Duration == nil {
+		v.Duration = f.Duration
+	}
+	if v.MinAvailable == nil {
+		v.MinAvailable = f.MinAvailable
+	}
+	if v.Categories == nil {
+		v.Categories = f.Categories
+	}
+	if v.Reasons == nil {
+		v.Reasons = f.Reasons
+	}
+	if v.NodeCreationThreshold == "" {
+		v.NodeCreationThreshold = f.NodeCreationThreshold
+	}
+	if v.CronJobCondition == "" {
+		v.CronJobCondition = f.CronJobCondition
+	}
+	if v.ResolveAfter == nil {
+		v.ResolveAfter = f.ResolveAfter
+	}
+	if v.IgnoreAfter == nil {
+		v.IgnoreAfter = f.IgnoreAfter
+	}
+	filled, err := NewMonitorTypeParameters(p.Type(), v)
+	if err != nil || reflect.DeepEqual(*p, filled) {
+		return false
+	}
+	*p = filled
+	return true
+}
+
+func (s *Sensor) lateInitialize(from *Sensor) bool {
+	from = from.DeepCopy()
+	filled := false
+	if s.Namespaces == nil && from.Namespaces != nil {
+		s.Namespaces, filled = from.Namespaces, true
+	}
+	if s.Services == nil && from.Services != nil {
+		s.Services, filled = from.Services, true
+	}
+	if s.Conditions == nil && from.Conditions != nil {
+		s.Conditions, filled = from.Conditions, true
+	}
+	if s.Labels == nil && from.Labels != nil {
+		s.Labels, filled = from.Labels, true
+	}
+	if s.Exclude == nil && from.Exclude != nil {
+		s.Exclude, filled = from.Exclude, true
+	}
+	return filled
+}
+
+// singleSensor returns the only sensor of the supplied cluster, or nil if the
+// cluster is unset or has no or several sensors.
+func singleSensor(sensors []Sensor, cluster string) *Sensor {
+	if cluster == "" {
+		return nil
+	}
+	var found *Sensor
+	for i := range sensors {
+		if sensors[i].Cluster != cluster {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = &sensors[i]
+	}
+	return found
+}
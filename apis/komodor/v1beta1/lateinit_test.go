@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestLateInitialize(t *testing.T) {
+	komodor := func() *RealtimeMonitorParameters {
+		return &RealtimeMonitorParameters{
+			Name: "from komodor",
+			Sensors: []Sensor{
+				{Cluster: "prod", Namespaces: []string{"default"}, Exclude: &SensorScope{Services: []string{"canary"}}},
+				{Cluster: "dev", Namespaces: []string{"a"}},
+				{Cluster: "dev", Namespaces: []string{"b"}},
+			},
+			Sinks: Sinks{Slack: []string{"alerts"}},
+			MonitorTypeParameters: MonitorTypeParameters{
+				Availability: &AvailabilityMonitor{Duration: ptr.To(30), MinAvailable: ptr.To(intstr.FromString("100%")), Categories: []string{"OOMKilled"}},
+			},
+			SinksOptions: map[string][]string{"notifyOn": {"OOMKilled"}, "mentions": {"@oncall"}},
+		}
+	}
+
+	type want struct {
+		p      *RealtimeMonitorParameters
+		filled bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		p      *RealtimeMonitorParameters
+		from   *RealtimeMonitorParameters
+		want   want
+	}{
+		"FillsUnsetFields": {
+			reason: "Unset variables, sensor fields and sink options should be filled.",
+			p: &RealtimeMonitorParameters{
+				Name:    "mine",
+				Sensors: []Sensor{{Cluster: "prod"}},
+				MonitorTypeParameters: MonitorTypeParameters{
+					Availability: &AvailabilityMonitor{Duration: ptr.To(60)},
+				},
+				SinksOptions: map[string][]string{"notifyOn": {"BackOff"}},
+			},
+			from: komodor(),
+			want: want{
+				p: &RealtimeMonitorParameters{
+					Name:    "mine",
+					Sensors: []Sensor{{Cluster: "prod", Namespaces: []string{"default"}, Exclude: &SensorScope{Services: []string{"canary"}}}},
+					MonitorTypeParameters: MonitorTypeParameters{
+						Availability: &AvailabilityMonitor{Duration: ptr.To(60), MinAvailable: ptr.To(intstr.FromString("100%")), Categories: []string{"OOMKilled"}},
+					},
+					SinksOptions: map[string][]string{"notifyOn": {"BackOff"}, "mentions": {"@oncall"}},
+				},
+				filled: true,
+			},
+		},
+		"NothingUnset": {
+			reason: "Parameters that set every field Komodor reports should not be filled.",
+			p: &RealtimeMonitorParameters{
+				Sensors: []Sensor{{Cluster: "prod", Namespaces: []string{"kube-system"}, Exclude: &SensorScope{}}},
+				MonitorTypeParameters: MonitorTypeParameters{
+					Availability: &AvailabilityMonitor{Duration: ptr.To(60), MinAvailable: ptr.To(intstr.FromInt32(1)), Categories: []string{}},
+				},
+				SinksOptions: map[string][]string{"notifyOn": nil, "mentions": nil},
+			},
+			from: komodor(),
+			want: want{
+				p: &RealtimeMonitorParameters{
+					Sensors: []Sensor{{Cluster: "prod", Namespaces: []string{"kube-system"}, Exclude: &SensorScope{}}},
+					MonitorTypeParameters: MonitorTypeParameters{
+						Availability: &AvailabilityMonitor{Duration: ptr.To(60), MinAvailable: ptr.To(intstr.FromInt32(1)), Categories: []string{}},
+					},
+					SinksOptions: map[string][]string{"notifyOn": nil, "mentions": nil},
+				},
+			},
+		},
+		"OtherTypeOrAmbiguousSensor": {
+			reason: "Variables of another monitor type and sensors of clusters with several sensors should not be filled.",
+			p: &RealtimeMonitorParameters{
+				Sensors:               []Sensor{{Cluster: "dev"}},
+				MonitorTypeParameters: MonitorTypeParameters{Node: &NodeMonitor{}},
+				SinksOptions:          map[string][]string{"notifyOn": {}, "mentions": {}},
+			},
+			from: komodor(),
+			want: want{
+				p: &RealtimeMonitorParameters{
+					Sensors:               []Sensor{{Cluster: "dev"}},
+					MonitorTypeParameters: MonitorTypeParameters{Node: &NodeMonitor{}},
+					SinksOptions:          map[string][]string{"notifyOn": {}, "mentions": {}},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			from := tc.from.DeepCopy()
+			filled := tc.p.LateInitialize(from)
+			if diff := cmp.Diff(tc.want.filled, filled); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want filled, +got filled:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.p, tc.p); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.from, from); diff != "" {
+				t.Errorf("\n%s\nLateInitialize(...): from was modified: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Labels and annotations of the RealtimeMonitors a MonitorSet creates.
+const (
+	// LabelKeyMonitorSet is the name of the MonitorSet that created a
+	// RealtimeMonitor. Names longer than a label value allows are shortened
+	// and suffixed with a hash. The MonitorSet is the monitor's controller
+	// owner; the label alone does not make a monitor part of a set.
+	LabelKeyMonitorSet = Group + "/monitor-set"
+
+	// AnnotationKeyCluster is the Komodor cluster a RealtimeMonitor was
+	// created for. Cluster names need not be valid label values.
+	AnnotationKeyCluster = Group + "/cluster"
+)
+
+// A ClusterGenerator selects Komodor clusters from the cluster inventory.
+// +kubebuilder:validation:XValidation:rule="has(self.names) || has(self.selector)",message="at least one of names or selector must be set"
+type ClusterGenerator struct {
+	// Names of Komodor clusters. Clusters not onboarded to Komodor are
+	// skipped until they are.
+	// +kubebuilder:validation:Optional
+	Names []string `json:"names,omitempty"`
+
+	// Selector matches Komodor clusters by their tags. An empty selector
+	// matches every cluster.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// MonitorSetParameters are the configurable fields of a MonitorSet.
+type MonitorSetParameters struct {
+	// Clusters generates the Komodor clusters to create a RealtimeMonitor
+	// for.
+	// +kubebuilder:validation:Required
+	Clusters ClusterGenerator `json:"clusters"`
+
+	// Template of the RealtimeMonitors. Each sensor watches the generated
+	// cluster, and each monitor is named after the template and the cluster.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self.sensors.all(s, !has(s.cluster) && !has(s.clusterRef) && !has(s.clusterSelector))",message="template sensors must not set cluster, clusterRef or clusterSelector"
+	Template RealtimeMonitorParameters `json:"template"`
+}
+
+// MonitorSetObservation are the observable fields of a MonitorSet.
+type MonitorSetObservation struct {
+	// Clusters the MonitorSet creates a RealtimeMonitor for.
+	Clusters []string `json:"clusters,omitempty"`
+}
+
+// A MonitorSetSpec defines the desired state of a MonitorSet.
+type MonitorSetSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       MonitorSetParameters `json:"forProvider"`
+}
+
+// A MonitorSetStatus represents the observed state of a MonitorSet.
+type MonitorSetStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          MonitorSetObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A MonitorSet creates a RealtimeMonitor from a template for each Komodor
+// cluster it generates, and updates and prunes them as the template changes
+// and clusters join or leave Komodor.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,komodor}
+type MonitorSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MonitorSetSpec   `json:"spec"`
+	Status MonitorSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MonitorSetList contains a list of MonitorSet
+type MonitorSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MonitorSet `json:"items"`
+}
+
+// MonitorSet type metadata.
+var (
+	MonitorSetKind             = reflect.TypeOf(MonitorSet{}).Name()
+	MonitorSetGroupKind        = schema.GroupKind{Group: Group, Kind: MonitorSetKind}.String()
+	MonitorSetKindAPIVersion   = MonitorSetKind + "." + SchemeGroupVersion.String()
+	MonitorSetGroupVersionKind = SchemeGroupVersion.WithKind(MonitorSetKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&MonitorSet{}, &MonitorSetList{})
+}
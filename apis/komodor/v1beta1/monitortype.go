@@ -0,0 +1,266 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Komodor monitor types.
+const (
+	MonitorTypeAvailability = "availability"
+	MonitorTypeNode         = "node"
+	MonitorTypePVC          = "PVC"
+	MonitorTypeJob          = "job"
+	MonitorTypeCronJob      = "cronJob"
+	MonitorTypeDeploy       = "deploy"
+	MonitorTypeWorkflow     = "workflow"
+)
+
+// MonitorTypeParameters select the type of a monitor. Exactly one field must
+// be set; it holds the variables that apply to that type.
+type MonitorTypeParameters struct {
+	// Availability fires when fewer replicas of a workload than required
+	// are available.
+	// +kubebuilder:validation:Optional
+	Availability *AvailabilityMonitor `json:"availability,omitempty"`
+
+	// Node fires when a node is not ready.
+	// +kubebuilder:validation:Optional
+	Node *NodeMonitor `json:"node,omitempty"`
+
+	// PVC fires when a persistent volume claim stays pending.
+	// +kubebuilder:validation:Optional
+	PVC *PVCMonitor `json:"pvc,omitempty"`
+
+	// Job fires when a job fails.
+	// +kubebuilder:validation:Optional
+	Job *JobMonitor `json:"job,omitempty"`
+
+	// CronJob fires when a cron job run fails.
+	// +kubebuilder:validation:Optional
+	CronJob *CronJobMonitor `json:"cronJob,omitempty"`
+
+	// Deploy fires when a deployment fails to roll out.
+	// +kubebuilder:validation:Optional
+	Deploy *DeployMonitor `json:"deploy,omitempty"`
+
+	// Workflow fires when a workflow fails.
+	// +kubebuilder:validation:Optional
+	Workflow *WorkflowMonitor `json:"workflow,omitempty"`
+}
+
+// AvailabilityMonitor variables.
+type AvailabilityMonitor struct {
+	// Duration in seconds the workload must be unavailable before the
+	// monitor fires.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=30
+	Duration *int `json:"duration,omitempty"`
+
+	// MinAvailable is the number or percentage of replicas that must be
+	// available, for example 2 or "85%".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:XValidation:rule="type(self) == int ? self >= 0 : self.matches('^[0-9]+%$')",message="minAvailable must be a non-negative number or a percentage"
+	// +kubebuilder:default="100%"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Categories of unavailability reasons that fire the monitor, for
+	// example OOMKilled or BackOff. All categories fire it if unset.
+	// +kubebuilder:validation:Optional
+	Categories []string `json:"categories,omitempty"`
+
+	// Reasons that fire the monitor.
+	// +kubebuilder:validation:Optional
+	Reasons []string `json:"reasons,omitempty"`
+
+	// ResolveAfter in seconds auto-resolves the issue.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ResolveAfter *int `json:"resolveAfter,omitempty"`
+
+	// IgnoreAfter in seconds stops tracking the issue.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	IgnoreAfter *int `json:"ignoreAfter,omitempty"`
+}
+
+// NodeMonitor variables.
+type NodeMonitor struct {
+	// Duration in seconds the node must be unhealthy before the monitor
+	// fires.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=60
+	Duration *int `json:"duration,omitempty"`
+
+	// NodeCreationThreshold ignores nodes younger than this duration, for
+	// example 5m.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	NodeCreationThreshold string `json:"nodeCreationThreshold,omitempty"`
+
+	// ResolveAfter in seconds auto-resolves the issue.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ResolveAfter *int `json:"resolveAfter,omitempty"`
+
+	// IgnoreAfter in seconds stops tracking the issue.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	IgnoreAfter *int `json:"ignoreAfter,omitempty"`
+}
+
+// PVCMonitor variables.
+type PVCMonitor struct {
+	// Duration in seconds the claim must be pending before the monitor
+	// fires.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=300
+	Duration *int `json:"duration,omitempty"`
+
+	// ResolveAfter in seconds auto-resolves the issue.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ResolveAfter *int `json:"resolveAfter,omitempty"`
+
+	// IgnoreAfter in seconds stops tracking the issue.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	IgnoreAfter *int `json:"ignoreAfter,omitempty"`
+}
+
+// JobMonitor has no variables.
+type JobMonitor struct{}
+
+// CronJobMonitor variables.
+type CronJobMonitor struct {
+	// CronJobCondition selects whether only the first failed run after a
+	// successful one fires the monitor, or any failed run.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=first;any
+	// +kubebuilder:default=first
+	CronJobCondition string `json:"cronJobCondition,omitempty"`
+}
+
+// DeployMonitor has no variables.
+type DeployMonitor struct{}
+
+// WorkflowMonitor has no variables.
+type WorkflowMonitor struct{}
+
+// Type returns the Komodor type of the selected monitor, or an empty string
+// if none is selected.
+func (p *MonitorTypeParameters) Type() string {
+	switch {
+	case p.Availability != nil:
+		return MonitorTypeAvailability
+	case p.Node != nil:
+		return MonitorTypeNode
+	case p.PVC != nil:
+		return MonitorTypePVC
+	case p.Job != nil:
+		return MonitorTypeJob
+	case p.CronJob != nil:
+		return MonitorTypeCronJob
+	case p.Deploy != nil:
+		return MonitorTypeDeploy
+	case p.Workflow != nil:
+		return MonitorTypeWorkflow
+	}
+	return ""
+}
+
+// Variables returns the variables of the selected monitor in the flat form
+// Komodor uses, or nil if the monitor has none.
+func (p *MonitorTypeParameters) Variables() *Variables {
+	switch {
+	case p.Availability != nil:
+		a := p.Availability
+		return &Variables{
+			Duration:     a.Duration,
+			MinAvailable: a.MinAvailable,
+			Categories:   a.Categories,
+			Reasons:      a.Reasons,
+			ResolveAfter: a.ResolveAfter,
+			IgnoreAfter:  a.IgnoreAfter,
+		}
+	case p.Node != nil:
+		n := p.Node
+		return &Variables{
+			Duration:              n.Duration,
+			NodeCreationThreshold: n.NodeCreationThreshold,
+			ResolveAfter:          n.ResolveAfter,
+			IgnoreAfter:           n.IgnoreAfter,
+		}
+	case p.PVC != nil:
+		return &Variables{
+			Duration:     p.PVC.Duration,
+			ResolveAfter: p.PVC.ResolveAfter,
+			IgnoreAfter:  p.PVC.IgnoreAfter,
+		}
+	case p.CronJob != nil:
+		return &Variables{CronJobCondition: p.CronJob.CronJobCondition}
+	}
+	return nil
+}
+
+// NewMonitorTypeParameters selects the monitor of the supplied Komodor type
+// and fills it from the supplied flat variables. Variables that do not apply
+// to the type are dropped.
+func NewMonitorTypeParameters(monitorType string, v *Variables) (MonitorTypeParameters, error) {
+	if v == nil {
+		v = &Variables{}
+	}
+	switch monitorType {
+	case MonitorTypeAvailability:
+		return MonitorTypeParameters{Availability: &AvailabilityMonitor{
+			Duration:     v.Duration,
+			MinAvailable: v.MinAvailable,
+			Categories:   v.Categories,
+			Reasons:      v.Reasons,
+			ResolveAfter: v.ResolveAfter,
+			IgnoreAfter:  v.IgnoreAfter,
+		}}, nil
+	case MonitorTypeNode:
+		return MonitorTypeParameters{Node: &NodeMonitor{
+			Duration:              v.Duration,
+			NodeCreationThreshold: v.NodeCreationThreshold,
+			ResolveAfter:          v.ResolveAfter,
+			IgnoreAfter:           v.IgnoreAfter,
+		}}, nil
+	case MonitorTypePVC:
+		return MonitorTypeParameters{PVC: &PVCMonitor{
+			Duration:     v.Duration,
+			ResolveAfter: v.ResolveAfter,
+			IgnoreAfter:  v.IgnoreAfter,
+		}}, nil
+	case MonitorTypeJob:
+		return MonitorTypeParameters{Job: &JobMonitor{}}, nil
+	case MonitorTypeCronJob:
+		return MonitorTypeParameters{CronJob: &CronJobMonitor{CronJobCondition: v.CronJobCondition}}, nil
+	case MonitorTypeDeploy:
+		return MonitorTypeParameters{Deploy: &DeployMonitor{}}, nil
+	case MonitorTypeWorkflow:
+		return MonitorTypeParameters{Workflow: &WorkflowMonitor{}}, nil
+	}
+	return MonitorTypeParameters{}, errors.Errorf("unknown monitor type %q", monitorType)
+}
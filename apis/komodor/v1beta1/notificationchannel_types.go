@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// NotificationChannelParameters describe the destinations of a
+// NotificationChannel.
+type NotificationChannelParameters struct {
+	// Sinks the channel notifies.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="has(self.slack) || has(self.slackSecretRefs) || has(self.teams) || has(self.teamsSecretRefs) || has(self.opsgenie) || has(self.opsgenieSecretRefs) || has(self.pagerDuty) || has(self.genericWebhook) || has(self.genericWebhookSecretRefs)",message="at least one sink must be configured"
+	// +kubebuilder:validation:XValidation:rule="!has(self.pagerDuty) || self.pagerDuty.all(p, has(p.integrationKey) || has(p.integrationKeySecretRef))",message="pagerDuty sinks require integrationKey or integrationKeySecretRef"
+	Sinks Sinks `json:"sinks"`
+
+	// SinksOptions are the notification options of the sinks, such as the
+	// categories to notify on.
+	// +kubebuilder:validation:Optional
+	SinksOptions map[string][]string `json:"sinksOptions,omitempty"`
+}
+
+// NotificationChannelObservation are the observable fields of a
+// NotificationChannel.
+type NotificationChannelObservation struct{}
+
+// A NotificationChannelSpec defines the desired state of a
+// NotificationChannel.
+type NotificationChannelSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       NotificationChannelParameters `json:"forProvider"`
+}
+
+// A NotificationChannelStatus represents the observed state of a
+// NotificationChannel.
+type NotificationChannelStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          NotificationChannelObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A NotificationChannel describes notification destinations shared by
+// RealtimeMonitors. Komodor has no notion of a channel: monitors referencing
+// one with sinkRefs or sinkSelector add its sinks and sink options to their
+// own, and are updated when the channel changes.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,komodor}
+type NotificationChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NotificationChannelSpec   `json:"spec"`
+	Status NotificationChannelStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NotificationChannelList contains a list of NotificationChannel
+type NotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationChannel `json:"items"`
+}
+
+// NotificationChannel type metadata.
+var (
+	NotificationChannelKind             = reflect.TypeOf(NotificationChannel{}).Name()
+	NotificationChannelGroupKind        = schema.GroupKind{Group: Group, Kind: NotificationChannelKind}.String()
+	NotificationChannelKindAPIVersion   = NotificationChannelKind + "." + SchemeGroupVersion.String()
+	NotificationChannelGroupVersionKind = SchemeGroupVersion.WithKind(NotificationChannelKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&NotificationChannel{}, &NotificationChannelList{})
+}
+
+// NotificationChannelName extracts the name of a referenced
+// NotificationChannel. It extracts nothing until the NotificationChannel is
+// ready.
+func NotificationChannelName() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		cr, ok := mg.(*NotificationChannel)
+		if !ok || !resource.IsConditionTrue(cr.GetCondition(xpv1.TypeReady)) {
+			return ""
+		}
+		return cr.GetName()
+	}
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A Sensor selects the Kubernetes resources a monitor watches.
+type Sensor struct {
+	// Cluster is the name of the Komodor cluster the sensor watches.
+	// +crossplane:generate:reference:type=Cluster
+	// +crossplane:generate:reference:extractor=ClusterName()
+	// +kubebuilder:validation:Optional
+	Cluster string `json:"cluster,omitempty"`
+
+	// ClusterRef references the Cluster whose Komodor cluster the sensor
+	// watches. The monitor waits until the Cluster is ready.
+	// +kubebuilder:validation:Optional
+	ClusterRef *xpv1.Reference `json:"clusterRef,omitempty"`
+
+	// ClusterSelector selects the Cluster whose Komodor cluster the sensor
+	// watches.
+	// +kubebuilder:validation:Optional
+	ClusterSelector *xpv1.Selector `json:"clusterSelector,omitempty"`
+
+	// Namespaces limits the sensor to these namespaces.
+	// +kubebuilder:validation:Optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Services limits the sensor to these services.
+	// +kubebuilder:validation:Optional
+	Services []string `json:"services,omitempty"`
+
+	// Conditions limits a node sensor to these node conditions.
+	// +kubebuilder:validation:Optional
+	Conditions []string `json:"conditions,omitempty"`
+
+	// Labels limits the sensor to resources carrying these labels, each
+	// written as key:value.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Pattern=`^[^:]+:.*$`
+	Labels []string `json:"labels,omitempty"`
+
+	// Exclude removes namespaces or services from the sensor's scope.
+	// +kubebuilder:validation:Optional
+	Exclude *SensorScope `json:"exclude,omitempty"`
+}
+
+// A SensorObservation is a sensor as Komodor reports it.
+type SensorObservation struct {
+	// Cluster is the name of the Komodor cluster the sensor watches.
+	Cluster string `json:"cluster,omitempty"`
+
+	// Namespaces the sensor is limited to.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Services the sensor is limited to.
+	Services []string `json:"services,omitempty"`
+
+	// Conditions a node sensor is limited to.
+	Conditions []string `json:"conditions,omitempty"`
+
+	// Labels the sensor is limited to, each written as key:value.
+	Labels []string `json:"labels,omitempty"`
+
+	// Exclude are the namespaces and services removed from the sensor's
+	// scope.
+	Exclude *SensorScope `json:"exclude,omitempty"`
+}
+
+// A SensorScope is a set of namespaces and services.
+type SensorScope struct {
+	// Namespaces in the scope.
+	// +kubebuilder:validation:Optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Services in the scope.
+	// +kubebuilder:validation:Optional
+	Services []string `json:"services,omitempty"`
+}
+
+// Sinks are the destinations a monitor notifies. Targets that are secrets,
+// such as webhook URLs or API keys, can be read from Secrets with the
+// SecretRefs fields so they appear neither in the spec nor in the status.
+type Sinks struct {
+	// Slack channels to notify.
+	// +kubebuilder:validation:Optional
+	Slack []string `json:"slack,omitempty"`
+
+	// SlackSecretRefs select Secret keys holding further Slack targets.
+	// +kubebuilder:validation:Optional
+	SlackSecretRefs []xpv1.SecretKeySelector `json:"slackSecretRefs,omitempty"`
+
+	// Teams channels to notify.
+	// +kubebuilder:validation:Optional
+	Teams []string `json:"teams,omitempty"`
+
+	// TeamsSecretRefs select Secret keys holding further Teams targets.
+	// +kubebuilder:validation:Optional
+	TeamsSecretRefs []xpv1.SecretKeySelector `json:"teamsSecretRefs,omitempty"`
+
+	// Opsgenie integrations to notify.
+	// +kubebuilder:validation:Optional
+	Opsgenie []string `json:"opsgenie,omitempty"`
+
+	// OpsgenieSecretRefs select Secret keys holding further Opsgenie
+	// targets.
+	// +kubebuilder:validation:Optional
+	OpsgenieSecretRefs []xpv1.SecretKeySelector `json:"opsgenieSecretRefs,omitempty"`
+
+	// PagerDuty services to notify.
+	// +kubebuilder:validation:Optional
+	PagerDuty []PagerDutySink `json:"pagerDuty,omitempty"`
+
+	// GenericWebhook integrations to notify.
+	// +kubebuilder:validation:Optional
+	GenericWebhook []string `json:"genericWebhook,omitempty"`
+
+	// GenericWebhookSecretRefs select Secret keys holding further generic
+	// webhook targets.
+	// +kubebuilder:validation:Optional
+	GenericWebhookSecretRefs []xpv1.SecretKeySelector `json:"genericWebhookSecretRefs,omitempty"`
+}
+
+// A PagerDutySink routes notifications to a PagerDuty service.
+// +kubebuilder:validation:XValidation:rule="!(has(self.integrationKey) && has(self.integrationKeySecretRef))",message="only one of integrationKey or integrationKeySecretRef may be set"
+type PagerDutySink struct {
+	// Channel is the name of the PagerDuty service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Channel string `json:"channel"`
+
+	// IntegrationKey of the PagerDuty service. Prefer
+	// IntegrationKeySecretRef, the key is a secret.
+	// +kubebuilder:validation:Optional
+	IntegrationKey string `json:"integrationKey,omitempty"`
+
+	// IntegrationKeySecretRef selects the Secret key holding the
+	// integration key of the PagerDuty service.
+	// +kubebuilder:validation:Optional
+	IntegrationKeySecretRef *xpv1.SecretKeySelector `json:"integrationKeySecretRef,omitempty"`
+
+	// PagerDutyAccountName is the PagerDuty account the service belongs to.
+	// +kubebuilder:validation:Optional
+	PagerDutyAccountName string `json:"pagerDutyAccountName,omitempty"`
+}
+
+// Variables tune when a monitor fires, as Komodor reports them for every
+// monitor type.
+type Variables struct {
+	// Duration in seconds a condition must hold before the monitor fires.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Duration *int `json:"duration,omitempty"`
+
+	// MinAvailable is the number or percentage of replicas that must be
+	// available, for example 2 or "85%".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:XValidation:rule="type(self) == int ? self >= 0 : self.matches('^[0-9]+%$')",message="minAvailable must be a non-negative number or a percentage"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Categories of failure reasons that trigger the monitor.
+	// +kubebuilder:validation:Optional
+	Categories []string `json:"categories,omitempty"`
+
+	// Reasons that trigger the monitor.
+	// +kubebuilder:validation:Optional
+	Reasons []string `json:"reasons,omitempty"`
+
+	// NodeCreationThreshold ignores nodes younger than this duration, for
+	// example 5m.
+	// +kubebuilder:validation:Optional
+	NodeCreationThreshold string `json:"nodeCreationThreshold,omitempty"`
+
+	// CronJobCondition selects whether the first or any failed run fires.
+	// +kubebuilder:validation:Optional
+	CronJobCondition string `json:"cronJobCondition,omitempty"`
+
+	// ResolveAfter in seconds auto-resolves the issue.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	ResolveAfter *int `json:"resolveAfter,omitempty"`
+
+	// IgnoreAfter in seconds stops tracking the issue.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	IgnoreAfter *int `json:"ignoreAfter,omitempty"`
+}
+
+// RealtimeMonitorParameters are the configurable fields of a RealtimeMonitor.
+// +kubebuilder:validation:XValidation:rule="has(self.notificationChannels) || has(self.sinkRefs) || has(self.sinkSelector) || (has(self.sinks) && (has(self.sinks.slack) || has(self.sinks.slackSecretRefs) || has(self.sinks.teams) || has(self.sinks.teamsSecretRefs) || has(self.sinks.opsgenie) || has(self.sinks.opsgenieSecretRefs) || has(self.sinks.pagerDuty) || has(self.sinks.genericWebhook) || has(self.sinks.genericWebhookSecretRefs)))",message="at least one sink or notification channel must be configured"
+// +kubebuilder:validation:XValidation:rule="[has(self.availability), has(self.node), has(self.pvc), has(self.job), has(self.cronJob), has(self.deploy), has(self.workflow)].filter(x, x).size() == 1",message="exactly one of availability, node, pvc, job, cronJob, deploy or workflow must be set"
+type RealtimeMonitorParameters struct {
+	// Name of the monitor.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Sensors select the resources the monitor watches.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Sensors []Sensor `json:"sensors"`
+
+	// Sinks are the destinations the monitor notifies, in addition to those
+	// of its NotificationChannels.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="!has(self.pagerDuty) || self.pagerDuty.all(p, has(p.integrationKey) || has(p.integrationKeySecretRef))",message="pagerDuty sinks require integrationKey or integrationKeySecretRef"
+	Sinks Sinks `json:"sinks,omitempty"`
+
+	// NotificationChannels are the names of NotificationChannels whose sinks
+	// and sink options the monitor notifies.
+	// +crossplane:generate:reference:type=NotificationChannel
+	// +crossplane:generate:reference:extractor=NotificationChannelName()
+	// +crossplane:generate:reference:refFieldName=SinkRefs
+	// +crossplane:generate:reference:selectorFieldName=SinkSelector
+	// +kubebuilder:validation:Optional
+	NotificationChannels []string `json:"notificationChannels,omitempty"`
+
+	// SinkRefs reference the NotificationChannels to notify.
+	// +kubebuilder:validation:Optional
+	SinkRefs []xpv1.Reference `json:"sinkRefs,omitempty"`
+
+	// SinkSelector selects the NotificationChannels to notify.
+	// +kubebuilder:validation:Optional
+	SinkSelector *xpv1.Selector `json:"sinkSelector,omitempty"`
+
+	// Whether the monitor is active.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=true
+	Active bool `json:"active"`
+
+	// MonitorTypeParameters select the type of the monitor and tune when it
+	// fires.
+	MonitorTypeParameters `json:",inline"`
+
+	// SinksOptions tune the notifications sent to sinks, for example the
+	// notifyOn reasons.
+	// +kubebuilder:validation:Optional
+	SinksOptions map[string][]string `json:"sinksOptions,omitempty"`
+}
+
+// RealtimeMonitorObservation are the observable fields of a RealtimeMonitor.
+type RealtimeMonitorObservation struct {
+	ID           string              `json:"id,omitempty"`
+	CreatedAt    string              `json:"createdAt,omitempty"`
+	UpdatedAt    string              `json:"updatedAt,omitempty"`
+	IsDeleted    bool                `json:"isDeleted,omitempty"`
+	Name         string              `json:"name,omitempty"`
+	Sensors      []SensorObservation `json:"sensors,omitempty"`
+	Sinks        *Sinks              `json:"sinks,omitempty"`
+	Active       bool                `json:"active,omitempty"`
+	Type         string              `json:"type,omitempty"`
+	Variables    *Variables          `json:"variables,omitempty"`
+	SinksOptions map[string][]string `json:"sinksOptions,omitempty"`
+
+	// SinkSecretsHash is an HMAC of the sink secrets last sent to Komodor,
+	// keyed by the UID of the RealtimeMonitor. Sink secrets themselves are
+	// never mirrored into the status.
+	SinkSecretsHash string `json:"sinkSecretsHash,omitempty"`
+
+	// DriftedFields are the fields of spec.forProvider that differed from
+	// the Komodor monitor when it was last observed.
+	DriftedFields []string `json:"driftedFields,omitempty"`
+}
+
+// An AdoptionPolicy controls whether a RealtimeMonitor without an external
+// name adopts an existing Komodor monitor.
+type AdoptionPolicy string
+
+// Adoption policies.
+const (
+	// AdoptionPolicyNever always creates a new monitor.
+	AdoptionPolicyNever AdoptionPolicy = "Never"
+
+	// AdoptionPolicyByName adopts the only existing monitor with the name
+	// and type of the RealtimeMonitor, and refuses to proceed if several
+	// exist.
+	AdoptionPolicyByName AdoptionPolicy = "ByName"
+)
+
+// A DeletionMode controls what deleting a RealtimeMonitor does to its Komodor
+// monitor.
+type DeletionMode string
+
+// Deletion modes.
+const (
+	// DeletionModeDelete deletes the monitor.
+	DeletionModeDelete DeletionMode = "Delete"
+
+	// DeletionModeDeactivate deactivates the monitor, keeping it and its
+	// history in Komodor.
+	DeletionModeDeactivate DeletionMode = "Deactivate"
+)
+
+// A RealtimeMonitorSpec defines the desired state of a RealtimeMonitor.
+// +kubebuilder:validation:XValidation:rule="!has(self.deactivatedNameSuffix) || (has(self.deletionMode) && self.deletionMode == 'Deactivate')",message="deactivatedNameSuffix requires deletionMode Deactivate"
+type RealtimeMonitorSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// +kubebuilder:validation:XValidation:rule="self.sensors.all(s, has(s.cluster) || has(s.clusterRef) || has(s.clusterSelector))",message="one of cluster, clusterRef or clusterSelector must be set on each sensor"
+	ForProvider RealtimeMonitorParameters `json:"forProvider"`
+
+	// AdoptionPolicy controls what happens when the RealtimeMonitor has no
+	// external name. Never creates a new monitor. ByName adopts the only
+	// existing monitor with the same name and type, creates one if none
+	// exists, and refuses to proceed if several exist.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Never;ByName
+	// +kubebuilder:default=Never
+	AdoptionPolicy AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// DeletionMode controls what happens to the monitor when the
+	// RealtimeMonitor is deleted with the Delete deletion policy. Delete
+	// deletes it. Deactivate keeps it, and its history, in Komodor but sets
+	// it inactive. The Orphan deletion policy leaves the monitor untouched
+	// in either mode.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Delete;Deactivate
+	// +kubebuilder:default=Delete
+	DeletionMode DeletionMode `json:"deletionMode,omitempty"`
+
+	// DeactivatedNameSuffix is appended to the monitor's name when it is
+	// deactivated instead of deleted, for example " (orphaned)", so that
+	// deactivated monitors stand out in Komodor.
+	// +kubebuilder:validation:Optional
+	DeactivatedNameSuffix string `json:"deactivatedNameSuffix,omitempty"`
+}
+
+// A RealtimeMonitorStatus represents the observed state of a RealtimeMonitor.
+type RealtimeMonitorStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RealtimeMonitorObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+
+// A RealtimeMonitor is a Komodor real time monitor.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.atProvider.type"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,komodor}
+type RealtimeMonitor struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RealtimeMonitorSpec   `json:"spec"`
+	Status RealtimeMonitorStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RealtimeMonitorList contains a list of RealtimeMonitor
+type RealtimeMonitorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RealtimeMonitor `json:"items"`
+}
+
+// RealtimeMonitor type metadata.
+var (
+	RealtimeMonitorKind             = reflect.TypeOf(RealtimeMonitor{}).Name()
+	RealtimeMonitorGroupKind        = schema.GroupKind{Group: Group, Kind: RealtimeMonitorKind}.String()
+	RealtimeMonitorKindAPIVersion   = RealtimeMonitorKind + "." + SchemeGroupVersion.String()
+	RealtimeMonitorGroupVersionKind = SchemeGroupVersion.WithKind(RealtimeMonitorKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RealtimeMonitor{}, &RealtimeMonitorList{})
+}
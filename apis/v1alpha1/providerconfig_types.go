@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -29,6 +30,144 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// SecondaryCredentials are used whenever Komodor rejects credentials.
+	// Point them at the new API key while rotating it, so requests keep
+	// succeeding until credentials reference the new key too, then remove
+	// them.
+	// +optional
+	SecondaryCredentials *ProviderCredentials `json:"secondaryCredentials,omitempty"`
+
+	// Endpoint of the Komodor API. Defaults to the public US endpoint.
+	// +optional
+	Endpoint *EndpointConfig `json:"endpoint,omitempty"`
+
+	// Retry configures how requests failing with a transient Komodor API
+	// error are retried.
+	// +optional
+	Retry *RetryConfig `json:"retry,omitempty"`
+
+	// ClusterInventoryTTL is how long the list of clusters in the Komodor
+	// account is cached when validating cluster names. Set to 0s to always
+	// list clusters. Defaults to 1m.
+	// +optional
+	ClusterInventoryTTL *metav1.Duration `json:"clusterInventoryTTL,omitempty"`
+
+	// RateLimit bounds the requests sent to the Komodor API with this
+	// ProviderConfig's API key. ProviderConfigs sharing an API key share one
+	// budget, the smallest of theirs. Defaults to the provider's
+	// --komodor-api-rps and --komodor-api-burst flags.
+	// +optional
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty"`
+
+	// Transport configures the connections to the Komodor API, e.g. to reach
+	// it through a proxy.
+	// +optional
+	Transport *TransportConfig `json:"transport,omitempty"`
+}
+
+// TransportConfig configures the connections to the Komodor API.
+type TransportConfig struct {
+	// ProxyURL of the HTTP(S) proxy requests to Komodor are sent through,
+	// e.g. http://proxy.example.com:3128. Defaults to the proxy selected by
+	// the provider's HTTPS_PROXY and NO_PROXY environment variables.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// CABundle holds PEM encoded CA certificates trusted in addition to the
+	// system's, e.g. the CA of a TLS inspecting proxy.
+	// +optional
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+
+	// MinTLSVersion is the lowest TLS version accepted. Defaults to 1.2.
+	// +kubebuilder:validation:Enum="1.2";"1.3"
+	// +optional
+	MinTLSVersion string `json:"minTLSVersion,omitempty"`
+
+	// Timeout bounds a single attempt of a request. Retried attempts get
+	// their own timeout. Defaults to 10s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// CABundleSource selects the key of a Secret or ConfigMap holding a CA
+// bundle.
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) != has(self.configMapRef)",message="exactly one of secretRef and configMapRef must be set"
+type CABundleSource struct {
+	// SecretRef selects the key of a Secret holding the bundle.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// ConfigMapRef selects the key of a ConfigMap holding the bundle.
+	// +optional
+	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty"`
+}
+
+// A ConfigMapKeySelector selects the key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key of the ConfigMap to select.
+	Key string `json:"key"`
+}
+
+// RateLimitConfig is a token bucket budget for Komodor API requests.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained request rate.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerSecond *int `json:"requestsPerSecond,omitempty"`
+
+	// Burst is the number of requests that may be sent at once.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int `json:"burst,omitempty"`
+}
+
+// EndpointConfig selects the Komodor API the provider talks to.
+type EndpointConfig struct {
+	// Region of the Komodor tenant. Ignored when url is set.
+	// +kubebuilder:validation:Enum=US;EU
+	// +kubebuilder:default=US
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// URL of the Komodor API, e.g. https://api.komodor.com. Takes precedence
+	// over region and may include a path prefix.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// RetryConfig bounds the retries of a single Komodor API request. Only
+// requests that are safe to repeat are retried, and a Retry-After sent by
+// Komodor is always honored.
+type RetryConfig struct {
+	// MaxRetries is the number of times a request is repeated after the
+	// first attempt. Set to 0 to disable retries. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	MaxRetries *int `json:"maxRetries,omitempty"`
+
+	// InitialBackoff is the wait before the first retry. It doubles with
+	// every retry and is jittered. Defaults to 500ms.
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// MaxBackoff caps the wait between two attempts. Defaults to 10s.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+
+	// MaxElapsedTime caps the total time spent on all attempts of a request.
+	// Defaults to 30s.
+	// +optional
+	MaxElapsedTime *metav1.Duration `json:"maxElapsedTime,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -43,17 +182,73 @@ type ProviderCredentials struct {
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// ClusterCount is the number of clusters in the Komodor account, as of
+	// the last credentials check.
+	// +optional
+	ClusterCount *int `json:"clusterCount,omitempty"`
+}
+
+// TypeCredentialsValid indicates whether Komodor accepts the credentials of
+// a ProviderConfig.
+const TypeCredentialsValid xpv1.ConditionType = "CredentialsValid"
+
+// Reasons a ProviderConfig's credentials are or are not valid.
+const (
+	ReasonValidCredentials   xpv1.ConditionReason = "ValidCredentials"
+	ReasonInvalidCredentials xpv1.ConditionReason = "InvalidCredentials"
+	ReasonCheckFailed        xpv1.ConditionReason = "CheckFailed"
+)
+
+// CredentialsValid returns a condition indicating that Komodor accepted the
+// credentials of a ProviderConfig.
+func CredentialsValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsValid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonValidCredentials,
+	}
+}
+
+// CredentialsInvalid returns a condition indicating that the credentials of
+// a ProviderConfig are missing or that Komodor rejected them.
+func CredentialsInvalid(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInvalidCredentials,
+		Message:            err.Error(),
+	}
+}
+
+// CredentialsUnknown returns a condition indicating that the credentials of
+// a ProviderConfig could not be checked, e.g. because Komodor was
+// unreachable.
+func CredentialsUnknown(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCredentialsValid,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCheckFailed,
+		Message:            err.Error(),
+	}
 }
 
 // +kubebuilder:object:root=true
 
 // A ProviderConfig configures a Komodor provider.
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="CLUSTERS",type="integer",JSONPath=".status.clusterCount"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:rbac:groups=komodor.crossplane.io,resources=providerconfigs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=komodor.crossplane.io,resources=providerconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 type ProviderConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -62,6 +257,23 @@ type ProviderConfig struct {
 	Status ProviderConfigStatus `json:"status,omitempty"`
 }
 
+// ReferencesSecret returns true if the credentials, secondary credentials or
+// CA bundle of the ProviderConfig are read from the supplied Secret.
+func (pc *ProviderConfig) ReferencesSecret(namespace, name string) bool {
+	for _, cd := range []*ProviderCredentials{&pc.Spec.Credentials, pc.Spec.SecondaryCredentials} {
+		if cd == nil || cd.Source != xpv1.CredentialsSourceSecret || cd.SecretRef == nil {
+			continue
+		}
+		if cd.SecretRef.Namespace == namespace && cd.SecretRef.Name == name {
+			return true
+		}
+	}
+	if t := pc.Spec.Transport; t != nil && t.CABundle != nil && t.CABundle.SecretRef != nil {
+		return t.CABundle.SecretRef.Namespace == namespace && t.CABundle.SecretRef.Name == name
+	}
+	return false
+}
+
 // +kubebuilder:object:root=true
 
 // ProviderConfigList contains a list of ProviderConfig.
@@ -33,6 +33,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	changelogsv1alpha1 "github.com/crossplane/crossplane-runtime/apis/changelogs/proto/v1alpha1"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -46,8 +47,10 @@ import (
 
 	"github.com/crossplane/provider-komodor/apis"
 	"github.com/crossplane/provider-komodor/apis/v1alpha1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
 	komodor "github.com/crossplane/provider-komodor/internal/controller"
 	"github.com/crossplane/provider-komodor/internal/features"
+	"github.com/crossplane/provider-komodor/internal/tracing"
 	"github.com/crossplane/provider-komodor/internal/version"
 )
 
@@ -63,11 +66,21 @@ func main() {
 
 		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
 
+		komodorAPIRPS   = app.Flag("komodor-api-rps", "The default maximum rate per second of requests sent to the Komodor API with one API key.").Default("5").Float64()
+		komodorAPIBurst = app.Flag("komodor-api-burst", "The default number of requests that may be sent to the Komodor API with one API key at once.").Default("10").Int()
+
 		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
 		enableManagementPolicies   = app.Flag("enable-management-policies", "Enable support for Management Policies.").Default("false").Envar("ENABLE_MANAGEMENT_POLICIES").Bool()
 		enableChangeLogs           = app.Flag("enable-changelogs", "Enable support for capturing change logs during reconciliation.").Default("false").Envar("ENABLE_CHANGE_LOGS").Bool()
 		changelogsSocketPath       = app.Flag("changelogs-socket-path", "Path for changelogs socket (if enabled)").Default("/var/run/changelogs/changelogs.sock").Envar("CHANGELOGS_SOCKET_PATH").String()
+		certsDir                   = app.Flag("certs-dir", "The directory that contains the webhook server key and certificate.").Default("/tls/server").Envar("TLS_SERVER_CERTS_DIR").String()
+		enableWebhooks             = app.Flag("enable-webhooks", "Serve the conversion webhooks the CRDs declare. Without them only the storage version of each resource can be used.").Default("true").Envar("ENABLE_WEBHOOKS").Bool()
+
+		tracingExporter    = app.Flag("tracing-exporter", "Where traces of RealtimeMonitor reconciles and Komodor API requests are exported to.").Default(tracing.ExporterNone).Enum(tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout)
+		tracingEndpoint    = app.Flag("tracing-otlp-endpoint", "The host:port of the OTLP gRPC collector traces are exported to.").Default("localhost:4317").String()
+		tracingInsecure    = app.Flag("tracing-otlp-insecure", "Export traces to the OTLP collector without TLS, e.g. to a collector on the same node.").Default("true").Bool()
+		tracingSampleRatio = app.Flag("tracing-sample-ratio", "The fraction of traces that are recorded.").Default("1").Float64()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -84,6 +97,17 @@ func main() {
 		ctrl.SetLogger(infoLogger)
 	}
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:    *tracingExporter,
+		Endpoint:    *tracingEndpoint,
+		Insecure:    *tracingInsecure,
+		SampleRatio: *tracingSampleRatio,
+		Version:     version.Version,
+	})
+	kingpin.FatalIfError(err, "Cannot set up tracing")
+
+	komodorclient.SetDefaultRateLimit(komodorclient.RateLimit{RequestsPerSecond: *komodorAPIRPS, Burst: *komodorAPIBurst})
+
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
@@ -106,6 +130,10 @@ func main() {
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
 		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: *certsDir,
+		}),
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Komodor APIs to scheme")
@@ -115,6 +143,7 @@ func main() {
 
 	metrics.Registry.MustRegister(metricRecorder)
 	metrics.Registry.MustRegister(stateMetrics)
+	metrics.Registry.MustRegister(komodorclient.Collectors()...)
 
 	o := controller.Options{
 		Logger:                  log,
@@ -169,5 +198,24 @@ func main() {
 	}
 
 	kingpin.FatalIfError(komodor.Setup(mgr, o), "Cannot setup Komodor controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+
+	// The CRDs declare a conversion webhook, so the API server fails every
+	// request for another version than the storage version unless it is
+	// served. Crossplane mounts a serving certificate into every provider it
+	// installs; running without one, e.g. out of cluster, must be explicit.
+	if *enableWebhooks {
+		if _, err := os.Stat(filepath.Join(*certsDir, "tls.crt")); err != nil {
+			kingpin.Fatalf("Cannot serve conversion webhooks: %v; pass --enable-webhooks=false to run without them", err)
+		}
+		kingpin.FatalIfError(komodor.SetupWebhooks(mgr), "Cannot setup Komodor webhooks")
+	}
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	// Flush the spans of the last reconciles before exiting.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if serr := shutdownTracing(ctx); serr != nil {
+		log.Info("Cannot flush traces", "error", serr)
+	}
+	cancel()
+	kingpin.FatalIfError(err, "Cannot start controller manager")
 }
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package examples
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	"github.com/crossplane/provider-komodor/apis"
+)
+
+// verbs are the verbs an RBAC rule may grant.
+var verbs = sets.New("get", "list", "watch", "create", "update", "patch", "delete", "deletecollection", "*")
+
+// TestExamples decodes every example manifest strictly into its Go type, so
+// malformed YAML and unknown fields fail the build instead of a deployment.
+func TestExamples(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatalf("apis.AddToScheme(...): %v", err)
+	}
+	if err := rbacv1.AddToScheme(s); err != nil {
+		t.Fatalf("rbacv1.AddToScheme(...): %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join("provider", "*.yaml"))
+	if err != nil {
+		t.Fatalf("Glob(...): %v", err)
+	}
+	for _, f := range files {
+		t.Run(f, func(t *testing.T) {
+			b, err := os.ReadFile(f) //nolint:gosec // The path comes from a glob of this directory.
+			if err != nil {
+				t.Fatalf("ReadFile(...): %v", err)
+			}
+
+			tm := &metav1.TypeMeta{}
+			if err := yaml.Unmarshal(b, tm); err != nil {
+				t.Fatalf("Unmarshal(...): %v", err)
+			}
+			obj, err := s.New(tm.GroupVersionKind())
+			if runtime.IsNotRegisteredError(err) {
+				// Crossplane package types are not part of this provider.
+				return
+			}
+			if err != nil {
+				t.Fatalf("New(%s): %v", tm.GroupVersionKind(), err)
+			}
+			if err := yaml.UnmarshalStrict(b, obj); err != nil {
+				t.Fatalf("UnmarshalStrict(...): %v", err)
+			}
+
+			cr, ok := obj.(*rbacv1.ClusterRole)
+			if !ok {
+				return
+			}
+			for i, r := range cr.Rules {
+				if len(r.APIGroups) == 0 || len(r.Resources) == 0 {
+					t.Errorf("rule %d: want apiGroups and resources, got %+v", i, r)
+				}
+				for _, v := range r.Verbs {
+					if !verbs.Has(v) {
+						t.Errorf("rule %d: unknown verb %q", i, v)
+					}
+				}
+			}
+		})
+	}
+}
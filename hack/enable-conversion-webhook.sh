@@ -0,0 +1,36 @@
+#!/usr/bin/env bash
+
+# Copyright 2025 The Crossplane Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Marks the supplied CRDs as converted by the provider's conversion webhook.
+# controller-gen has no marker for this. Crossplane fills in the webhook
+# service and CA bundle when it installs the package.
+set -euo pipefail
+
+for crd in "$@"; do
+  tmp="$(mktemp)"
+  awk '
+    { print }
+    /^spec:$/ && !done {
+      print "  conversion:"
+      print "    strategy: Webhook"
+      print "    webhook:"
+      print "      conversionReviewVersions:"
+      print "      - v1"
+      done = 1
+    }
+  ' "${crd}" > "${tmp}"
+  mv "${tmp}" "${crd}"
+done
@@ -0,0 +1,57 @@
+package komodor
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// WithSecondaryAPIKey sets an API key requests fall back to when Komodor
+// rejects the primary one, so keys can be rotated without failed requests.
+func WithSecondaryAPIKey(key string) Option {
+	return func(c *Client) {
+		c.secondaryAPIKey = key
+	}
+}
+
+// apiKeyTransport is an http.RoundTripper that authenticates requests with
+// one of two API keys. A request Komodor answers with 401 Unauthorized is
+// repeated once with the other key, and the key Komodor accepted is used for
+// the requests that follow.
+type apiKeyTransport struct {
+	next    http.RoundTripper
+	keys    [2]string
+	current atomic.Int32
+}
+
+func newAPIKeyTransport(next http.RoundTripper, primary, secondary string) *apiKeyTransport {
+	return &apiKeyTransport{next: next, keys: [2]string{primary, secondary}}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	i := t.current.Load()
+	resp, err := t.next.RoundTrip(withAPIKey(req, t.keys[i]))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	r, rerr := rewind(req)
+	if rerr != nil {
+		return resp, nil
+	}
+	discard(resp)
+
+	j := 1 - i
+	resp, err = t.next.RoundTrip(withAPIKey(r, t.keys[j]))
+	if err == nil && resp.StatusCode != http.StatusUnauthorized {
+		t.current.CompareAndSwap(i, j)
+	}
+	return resp, err
+}
+
+// withAPIKey returns a copy of req authenticated with the supplied key.
+func withAPIKey(req *http.Request, key string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set(apiKeyHeader, key)
+	return r
+}
@@ -0,0 +1,79 @@
+package komodor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAPIKeyTransport(t *testing.T) {
+	type want struct {
+		statuses []int
+		sent     []string
+	}
+
+	cases := map[string]struct {
+		reason   string
+		accepted string
+		want     want
+	}{
+		"PrimaryAccepted": {
+			reason:   "Requests should be sent with the primary key while Komodor accepts it.",
+			accepted: "primary",
+			want: want{
+				statuses: []int{http.StatusOK, http.StatusOK},
+				sent:     []string{"primary", "primary"},
+			},
+		},
+		"SecondaryAccepted": {
+			reason:   "A request rejected with the primary key should be repeated with the secondary key, which later requests should use first.",
+			accepted: "secondary",
+			want: want{
+				statuses: []int{http.StatusOK, http.StatusOK},
+				sent:     []string{"primary", "secondary", "secondary"},
+			},
+		},
+		"BothRejected": {
+			reason: "A request rejected with both keys should return Komodor's 401.",
+			want: want{
+				statuses: []int{http.StatusUnauthorized, http.StatusUnauthorized},
+				sent:     []string{"primary", "secondary", "primary", "secondary"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := want{}
+			rt := newAPIKeyTransport(roundTripFn(func(r *http.Request) (*http.Response, error) {
+				key := r.Header.Get(apiKeyHeader)
+				got.sent = append(got.sent, key)
+				if b, _ := io.ReadAll(r.Body); string(b) != "{}" {
+					t.Errorf("\n%s\nRoundTrip(...): want body {}, got %q", tc.reason, b)
+				}
+				if key != tc.accepted {
+					return response(http.StatusUnauthorized, nil), nil
+				}
+				return response(http.StatusOK, nil), nil
+			}), "primary", "secondary")
+
+			for range 2 {
+				req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://komodor.test/", strings.NewReader("{}"))
+				resp, err := rt.RoundTrip(req)
+				if err != nil {
+					t.Fatalf("\n%s\nRoundTrip(...): unexpected error: %v", tc.reason, err)
+				}
+				_ = resp.Body.Close()
+				got.statuses = append(got.statuses, resp.StatusCode)
+			}
+
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package komodor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	apisv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
+)
+
+// A ClientCache reuses Komodor clients across reconciles, so their HTTP
+// connections, rate limiters and caches outlive a single reconcile. Clients
+// are keyed by ProviderConfig UID and replaced as soon as the ProviderConfig
+// spec, the credentials or the CA bundle it resolves to change. It is safe
+// for concurrent use.
+type ClientCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]cachedClient
+}
+
+type cachedClient struct {
+	version string
+	client  *Client
+}
+
+// caches are all client caches, so the clients of a deleted ProviderConfig
+// can be evicted from every controller's cache at once.
+var caches = struct {
+	mu  sync.Mutex
+	all []*ClientCache
+}{}
+
+// NewClientCache returns an empty client cache. Its clients are evicted by
+// EvictAll. Caches are meant to be created once per controller.
+func NewClientCache() *ClientCache {
+	c := &ClientCache{entries: map[types.UID]cachedClient{}}
+	caches.mu.Lock()
+	defer caches.mu.Unlock()
+	caches.all = append(caches.all, c)
+	return c
+}
+
+// clientVersion identifies the ProviderConfig generation, credentials and CA
+// bundle a client was built from without retaining the credentials
+// themselves.
+func clientVersion(pc *apisv1alpha1.ProviderConfig, keys APIKeys, caBundle []byte) string {
+	d := sha256.New()
+	// Separate the inputs so moving bytes between them changes the version.
+	for _, b := range [][]byte{keys.Primary, keys.Secondary, caBundle} {
+		d.Write(b)
+		d.Write([]byte{0})
+	}
+	h := d.Sum(nil)
+	return strconv.FormatInt(pc.GetGeneration(), 10) + "/" + hex.EncodeToString(h)
+}
+
+// Get returns the cached client of the supplied ProviderConfig if it was
+// built from the same spec, API keys and CA bundle, and calls build to
+// replace it otherwise. The idle connections of a replaced client are
+// closed.
+func (c *ClientCache) Get(pc *apisv1alpha1.ProviderConfig, keys APIKeys, caBundle []byte, build func() (*Client, error)) (*Client, error) {
+	v := clientVersion(pc, keys, caBundle)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[pc.GetUID()]
+	if ok && e.version == v {
+		return e.client, nil
+	}
+	cl, err := build()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		e.client.CloseIdleConnections()
+	}
+	c.entries[pc.GetUID()] = cachedClient{version: v, client: cl}
+	return cl, nil
+}
+
+// Evict drops the cached client of the ProviderConfig with the supplied UID
+// and closes its idle connections.
+func (c *ClientCache) Evict(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[uid]; ok {
+		e.client.CloseIdleConnections()
+		delete(c.entries, uid)
+	}
+}
+
+// EvictAll drops the cached clients of the ProviderConfig with the supplied
+// UID from every client cache, and releases its rate limit budget.
+func EvictAll(uid types.UID) {
+	caches.mu.Lock()
+	defer caches.mu.Unlock()
+	for _, c := range caches.all {
+		c.Evict(uid)
+	}
+	sharedLimiters.Release(string(uid))
+}
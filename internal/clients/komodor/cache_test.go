@@ -0,0 +1,187 @@
+package komodor
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apisv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
+)
+
+func TestClientCacheGet(t *testing.T) {
+	pc := func(uid types.UID, gen int64) *apisv1alpha1.ProviderConfig {
+		return &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{UID: uid, Generation: gen}}
+	}
+
+	type call struct {
+		pc       *apisv1alpha1.ProviderConfig
+		keys     APIKeys
+		caBundle string
+	}
+	keys := func(primary, secondary string) APIKeys {
+		k := APIKeys{Primary: []byte(primary)}
+		if secondary != "" {
+			k.Secondary = []byte(secondary)
+		}
+		return k
+	}
+
+	cases := map[string]struct {
+		reason string
+		calls  [2]call
+		want   int
+	}{
+		"Unchanged": {
+			reason: "The same ProviderConfig and credentials should reuse the cached client.",
+			calls:  [2]call{{pc("a", 1), keys("key", ""), ""}, {pc("a", 1), keys("key", ""), ""}},
+			want:   1,
+		},
+		"CredentialsRotated": {
+			reason: "Changed credentials should replace the cached client.",
+			calls:  [2]call{{pc("a", 1), keys("key", ""), ""}, {pc("a", 1), keys("rotated", ""), ""}},
+			want:   2,
+		},
+		"SecondaryCredentialsAdded": {
+			reason: "Adding secondary credentials should replace the cached client.",
+			calls:  [2]call{{pc("a", 1), keys("key", ""), ""}, {pc("a", 1), keys("key", "next"), ""}},
+			want:   2,
+		},
+		"CABundleChanged": {
+			reason: "A changed CA bundle should replace the cached client.",
+			calls:  [2]call{{pc("a", 1), keys("key", ""), "ca"}, {pc("a", 1), keys("key", ""), "renewed"}},
+			want:   2,
+		},
+		"SpecChanged": {
+			reason: "A new ProviderConfig generation should replace the cached client.",
+			calls:  [2]call{{pc("a", 1), keys("key", ""), ""}, {pc("a", 2), keys("key", ""), ""}},
+			want:   2,
+		},
+		"DifferentProviderConfigs": {
+			reason: "Different ProviderConfigs should get their own clients.",
+			calls:  [2]call{{pc("a", 1), keys("key", ""), ""}, {pc("b", 1), keys("key", ""), ""}},
+			want:   2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := NewClientCache()
+			builds := 0
+			for _, c := range tc.calls {
+				if _, err := cache.Get(c.pc, c.keys, []byte(c.caBundle), func() (*Client, error) {
+					builds++
+					return NewClient(string(c.keys.Primary), c.keys.Options()...), nil
+				}); err != nil {
+					t.Fatalf("\n%s\nGet(...): unexpected error: %v", tc.reason, err)
+				}
+			}
+			if builds != tc.want {
+				t.Errorf("\n%s\nGet(...): want %d builds, got %d", tc.reason, tc.want, builds)
+			}
+		})
+	}
+}
+
+func TestClientCacheClosesIdleConnections(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, s http.ConnState) {
+		if s != http.StateClosed {
+			return
+		}
+		select {
+		case closed <- struct{}{}:
+		default:
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+	base, _ := url.Parse(srv.URL)
+
+	pc := &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{UID: "a", Generation: 1}}
+	build := func(key string) func() (*Client, error) {
+		return func() (*Client, error) {
+			// A minimum TLS version gives the client a transport of its own.
+			return NewClient(key, WithBaseURL(base), WithMinTLSVersion(tls.VersionTLS12)), nil
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		drop   func(c *ClientCache)
+	}{
+		"Replaced": {
+			reason: "Replacing a cached client should close its idle connections.",
+			drop: func(c *ClientCache) {
+				_, _ = c.Get(pc, APIKeys{Primary: []byte("rotated")}, nil, build("rotated"))
+			},
+		},
+		"Evicted": {
+			reason: "Evicting a cached client should close its idle connections.",
+			drop: func(c *ClientCache) {
+				c.Evict(pc.GetUID())
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := NewClientCache()
+			cl, err := cache.Get(pc, APIKeys{Primary: []byte("key")}, nil, build("key"))
+			if err != nil {
+				t.Fatalf("\n%s\nGet(...): unexpected error: %v", tc.reason, err)
+			}
+			resp, err := cl.doRequest(context.Background(), http.MethodGet, "/", nil, nil)
+			if err != nil {
+				t.Fatalf("\n%s\ndoRequest(...): unexpected error: %v", tc.reason, err)
+			}
+			_ = resp.Body.Close()
+
+			tc.drop(cache)
+
+			select {
+			case <-closed:
+			case <-time.After(5 * time.Second):
+				t.Errorf("\n%s\nthe idle connection of the dropped client is still open", tc.reason)
+			}
+		})
+	}
+}
+
+func TestEvictAll(t *testing.T) {
+	pc := func(uid types.UID) *apisv1alpha1.ProviderConfig {
+		return &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{UID: uid, Generation: 1}}
+	}
+	keys := APIKeys{Primary: []byte("key")}
+	build := func() (*Client, error) { return NewClient("key"), nil }
+
+	a, b := NewClientCache(), NewClientCache()
+	for _, c := range []*ClientCache{a, b} {
+		for _, uid := range []types.UID{"deleted", "kept"} {
+			if _, err := c.Get(pc(uid), keys, nil, build); err != nil {
+				t.Fatalf("Get(...): unexpected error: %v", err)
+			}
+		}
+	}
+
+	EvictAll("deleted")
+
+	for i, c := range []*ClientCache{a, b} {
+		if _, ok := c.entries["deleted"]; ok {
+			t.Errorf("EvictAll(...): cache %d still holds the client of the deleted ProviderConfig", i)
+		}
+		if _, ok := c.entries["kept"]; !ok {
+			t.Errorf("EvictAll(...): cache %d dropped the client of another ProviderConfig", i)
+		}
+	}
+}
@@ -3,55 +3,113 @@ package komodor
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 )
 
 const (
-	defaultBaseURL  = "https://api.komodor.com/api/v2/realtime-monitors/config"
-	clustersBaseURL = "https://api.komodor.com/api/v2/clusters"
-	apiKeyHeader    = "X-API-KEY"
+	monitorsPath = "/api/v2/realtime-monitors/config"
+	clustersPath = "/api/v2/clusters"
+	apiKeyHeader = "X-API-KEY"
+
+	// defaultTimeout bounds a single attempt of a request.
+	defaultTimeout = 10 * time.Second
 )
 
-// NotFoundError represents a 404 Not Found error from the Komodor API
-type NotFoundError struct {
-	ID string
+// Client is a Komodor API client.
+type Client struct {
+	baseURL         *url.URL
+	apiKey          string
+	secondaryAPIKey string
+	retryPolicy     RetryPolicy
+	timeout         time.Duration
+	inventory       *ClusterInventory
+	inventoryTTL    time.Duration
+	rateLimit       RateLimit
+	rateLimitOwner  string
+	limiters        *Limiters
+	proxyURL        *url.URL
+	caBundle        []byte
+	minTLSVersion   uint16
+	providerConfig  string
+	httpClient      *http.Client
+
+	// ownTransport is the transport of a client that does not share
+	// http.DefaultTransport, if any.
+	ownTransport *http.Transport
 }
 
-func (e *NotFoundError) Error() string {
-	return fmt.Sprintf("monitor with ID %s not found", e.ID)
-}
+// An Option configures a Client.
+type Option func(*Client)
 
-// Client is a Komodor API client.
-type Client struct {
-	baseURL    *url.URL
-	apiKey     string
-	httpClient *http.Client
+// WithBaseURL sets the Komodor API base URL all request paths are derived
+// from.
+func WithBaseURL(u *url.URL) Option {
+	return func(c *Client) {
+		c.baseURL = u
+	}
 }
 
 // NewClient creates a new Komodor API client.
-func NewClient(apiKey string) *Client {
-	base, _ := url.Parse(defaultBaseURL)
-	return &Client{
-		baseURL:    base,
-		apiKey:     apiKey,
-		httpClient: &http.Client{Timeout: 10 * time.Second},
+func NewClient(apiKey string, opts ...Option) *Client {
+	base, _ := url.Parse(DefaultBaseURL)
+	c := &Client{
+		baseURL:      base,
+		apiKey:       apiKey,
+		retryPolicy:  DefaultRetryPolicy,
+		timeout:      defaultTimeout,
+		inventoryTTL: DefaultClusterInventoryTTL,
+		limiters:     sharedLimiters,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	// Retries sit above the rate limiter, so every attempt spends a token.
+	t := c.transport()
+	if own, ok := t.(*http.Transport); ok && t != http.DefaultTransport {
+		c.ownTransport = own
+	}
+	t = &rateLimitTransport{next: t, limiter: c.limiters.Get(c.credentialKey(), c.rateLimitOwner, c.rateLimit)}
+	if c.secondaryAPIKey != "" {
+		t = newAPIKeyTransport(t, c.apiKey, c.secondaryAPIKey)
 	}
+	// Metrics and spans sit above the retries, so they cover a request as
+	// a whole.
+	t = newRetryTransport(t, c.retryPolicy, c.timeout)
+	t = &traceTransport{next: t, providerConfig: c.providerConfig}
+	t = &metricsTransport{next: t, providerConfig: c.providerConfig}
+	c.httpClient = &http.Client{Transport: t}
+	return c
 }
 
-// doRequest executes an HTTP request with authentication.
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+// doRequest executes an HTTP request with authentication. The path is
+// relative to the client's base URL.
+func (c *Client) doRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	req, err := c.newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(req)
+}
+
+// newRequest builds an authenticated request for the supplied path relative
+// to the client's base URL.
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
 	// Use url.JoinPath to properly append the path to the base URL
 	u, err := url.JoinPath(c.baseURL.String(), path)
 	if err != nil {
 		return nil, err
 	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
 
 	// URL construction completed
 
@@ -64,7 +122,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		buf = bytes.NewBuffer(b)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u, buf)
+	req, err := http.NewRequestWithContext(withOperation(ctx, method, path), method, u, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -72,60 +130,26 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	return c.httpClient.Do(req)
+	return req, nil
 }
 
-// ListMonitors fetches all monitors.
+// ListMonitors fetches all monitors, following pagination.
 func (c *Client) ListMonitors(ctx context.Context) ([]Monitor, error) {
-	resp, err := c.doRequest(ctx, "GET", "", nil)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		if cerr := resp.Body.Close(); cerr != nil {
-			_ = cerr // explicitly ignore
-		}
-	}()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
-	}
-
-	// Read the response body to debug the issue
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Try to unmarshal as array first
-	var monitors []Monitor
-	if err := json.Unmarshal(body, &monitors); err != nil {
-		// If that fails, try to unmarshal as object with data field
-		var response struct {
-			Data []Monitor `json:"data"`
-		}
-		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal monitors response: %w, body: %s", err, string(body[:min(len(body), 200)]))
-		}
-		monitors = response.Data
-	}
-
-	return monitors, nil
+	return collect(c.Monitors(ctx))
 }
 
-// min returns the minimum of two integers
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
+// Monitors returns an iterator over all monitors. Pages are fetched as the
+// iteration proceeds, which keeps memory bounded on large accounts.
+func (c *Client) Monitors(ctx context.Context) iter.Seq2[Monitor, error] {
+	return paginate(ctx, c, monitorsPath, decodeMonitorsPage)
 }
 
 // GetMonitor fetches a monitor by ID.
 func (c *Client) GetMonitor(ctx context.Context, id string) (*Monitor, error) {
-	path := fmt.Sprintf("/%s", id)
+	path := fmt.Sprintf("%s/%s", monitorsPath, id)
 	// Making GET request to Komodor API
 
-	resp, err := c.doRequest(ctx, "GET", path, nil)
+	resp, err := c.doRequest(ctx, "GET", path, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -137,12 +161,8 @@ func (c *Client) GetMonitor(ctx context.Context, id string) (*Monitor, error) {
 
 	// Response received from Komodor API
 
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, &NotFoundError{ID: id}
-	}
-
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+		return nil, newAPIError(resp)
 	}
 
 	var m Monitor
@@ -156,7 +176,19 @@ func (c *Client) GetMonitor(ctx context.Context, id string) (*Monitor, error) {
 
 // CreateMonitor creates a new monitor.
 func (c *Client) CreateMonitor(ctx context.Context, monitor *Monitor) (*Monitor, error) {
-	resp, err := c.doRequest(ctx, "POST", "", monitor)
+	req, err := c.newRequest(ctx, "POST", monitorsPath, nil, monitor)
+	if err != nil {
+		return nil, err
+	}
+	// The idempotency key lets the retrying transport repeat the create
+	// without risking a duplicate monitor.
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(idempotencyKeyHeader, key)
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +198,7 @@ func (c *Client) CreateMonitor(ctx context.Context, monitor *Monitor) (*Monitor,
 		}
 	}()
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+		return nil, newAPIError(resp)
 	}
 	var m Monitor
 	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
@@ -175,9 +207,18 @@ func (c *Client) CreateMonitor(ctx context.Context, monitor *Monitor) (*Monitor,
 	return &m, nil
 }
 
+// newIdempotencyKey returns a random key identifying one logical request.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("cannot generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
 // UpdateMonitor updates an existing monitor by ID.
 func (c *Client) UpdateMonitor(ctx context.Context, id string, monitor *Monitor) (*Monitor, error) {
-	resp, err := c.doRequest(ctx, "PATCH", fmt.Sprintf("/%s", id), monitor)
+	resp, err := c.doRequest(ctx, "PATCH", fmt.Sprintf("%s/%s", monitorsPath, id), nil, monitor)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +228,7 @@ func (c *Client) UpdateMonitor(ctx context.Context, id string, monitor *Monitor)
 		}
 	}()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+		return nil, newAPIError(resp)
 	}
 	var m Monitor
 	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
@@ -198,7 +239,7 @@ func (c *Client) UpdateMonitor(ctx context.Context, id string, monitor *Monitor)
 
 // DeleteMonitor deletes a monitor by ID.
 func (c *Client) DeleteMonitor(ctx context.Context, id string) error {
-	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/%s", id), nil)
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/%s", monitorsPath, id), nil, nil)
 	if err != nil {
 		return err
 	}
@@ -208,33 +249,11 @@ func (c *Client) DeleteMonitor(ctx context.Context, id string) error {
 		}
 	}()
 	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("unexpected status: %s", resp.Status)
+		return newAPIError(resp)
 	}
 	return nil
 }
 
-// IsNotFound returns true if the error is a 404 Not Found from the Komodor API.
-func IsNotFound(err error) bool {
-	if err == nil {
-		return false
-	}
-	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-		return false
-	}
-
-	// Check for our custom NotFoundError
-	var notFoundErr *NotFoundError
-	if errors.As(err, &notFoundErr) {
-		return true
-	}
-
-	// Check for common 404 patterns in error string
-	if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "404 Not Found") {
-		return true
-	}
-	return false
-}
-
 // Cluster represents a Komodor cluster
 type Cluster struct {
 	ID           string            `json:"id,omitempty"`
@@ -250,69 +269,44 @@ type ClustersResponse struct {
 	Data struct {
 		Clusters []Cluster `json:"clusters"`
 	} `json:"data"`
+	Meta PageMeta `json:"meta"`
 }
 
-// ListClusters fetches all clusters from Komodor
+// ListClusters fetches all clusters from Komodor, following pagination. The
+// result refreshes the shared cluster inventory, if one is configured.
 func (c *Client) ListClusters(ctx context.Context) ([]Cluster, error) {
-	clustersURL, _ := url.Parse(clustersBaseURL)
-
-	req, err := http.NewRequestWithContext(ctx, "GET", clustersURL.String(), nil)
+	clusters, err := c.listClusters(ctx)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set(apiKeyHeader, c.apiKey)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	if c.inventory != nil {
+		c.inventory.Set(c.credentialKey(), clusters)
 	}
-	defer func() {
-		if cerr := resp.Body.Close(); cerr != nil {
-			_ = cerr // explicitly ignore
-		}
-	}()
+	return clusters, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
-	}
+func (c *Client) listClusters(ctx context.Context) ([]Cluster, error) {
+	return collect(c.Clusters(ctx))
+}
 
-	var response ClustersResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, err
-	}
-	return response.Data.Clusters, nil
+// Clusters returns an iterator over all clusters. Pages are fetched as the
+// iteration proceeds.
+func (c *Client) Clusters(ctx context.Context) iter.Seq2[Cluster, error] {
+	return paginate(ctx, c, clustersPath, decodeClustersPage)
 }
 
-// ValidateCluster checks if a cluster exists using the proper clusters API
+// ValidateCluster checks if a cluster exists using the proper clusters API.
+// The complete, possibly cached, inventory is searched before a cluster is
+// reported as missing.
 func (c *Client) ValidateCluster(ctx context.Context, clusterName string) (bool, error) {
-	clusters, err := c.ListClusters(ctx)
+	clusters, err := c.CachedClusters(ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to list clusters to validate cluster: %w", err)
 	}
-
-	// Check if the cluster exists
 	for _, cluster := range clusters {
 		if cluster.Name == clusterName {
 			return true, nil
 		}
 	}
-
 	return false, nil
 }
-
-// Monitor is a struct representing a Komodor Real Time Monitor.
-// Fill this out based on the API schema.
-type Monitor struct {
-	ID           string                   `json:"id,omitempty"`
-	CreatedAt    string                   `json:"createdAt,omitempty"`
-	UpdatedAt    string                   `json:"updatedAt,omitempty"`
-	IsDeleted    bool                     `json:"isDeleted,omitempty"`
-	Name         string                   `json:"name"`
-	Sensors      []map[string]interface{} `json:"sensors,omitempty"`
-	Sinks        map[string]interface{}   `json:"sinks,omitempty"`
-	Active       bool                     `json:"active"`
-	Type         string                   `json:"type"`
-	Variables    map[string]interface{}   `json:"variables,omitempty"`
-	SinksOptions map[string][]string      `json:"sinksOptions,omitempty"`
-}
@@ -0,0 +1,265 @@
+package komodor
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
+)
+
+// DefaultBaseURL is the Komodor API used when a ProviderConfig does not
+// configure an endpoint.
+const DefaultBaseURL = "https://api.komodor.com"
+
+// RegionBaseURLs maps the regions accepted by a ProviderConfig endpoint to
+// their Komodor API base URLs.
+var RegionBaseURLs = map[string]string{
+	"US": DefaultBaseURL,
+	"EU": "https://api.eu.komodor.com",
+}
+
+// BaseURL returns the Komodor API base URL selected by the supplied endpoint
+// configuration. An explicit URL wins over a region.
+func BaseURL(ec *apisv1alpha1.EndpointConfig) (*url.URL, error) {
+	raw := DefaultBaseURL
+	switch {
+	case ec == nil:
+	case ec.URL != "":
+		raw = ec.URL
+	case ec.Region != "":
+		r, ok := RegionBaseURLs[ec.Region]
+		if !ok {
+			return nil, errors.Errorf("unknown Komodor region %q", ec.Region)
+		}
+		raw = r
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse Komodor API URL %q", raw)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.Errorf("Komodor API URL %q must be absolute", raw)
+	}
+	return u, nil
+}
+
+// Retry returns the retry policy selected by the supplied configuration.
+// Unset fields keep their DefaultRetryPolicy value.
+func Retry(rc *apisv1alpha1.RetryConfig) RetryPolicy {
+	p := DefaultRetryPolicy
+	if rc == nil {
+		return p
+	}
+	if rc.MaxRetries != nil {
+		p.MaxRetries = *rc.MaxRetries
+	}
+	if rc.InitialBackoff != nil {
+		p.InitialBackoff = rc.InitialBackoff.Duration
+	}
+	if rc.MaxBackoff != nil {
+		p.MaxBackoff = rc.MaxBackoff.Duration
+	}
+	if rc.MaxElapsedTime != nil {
+		p.MaxElapsed = rc.MaxElapsedTime.Duration
+	}
+	return p
+}
+
+// ProviderConfigOptions returns the client options configured by the
+// supplied ProviderConfig.
+func ProviderConfigOptions(pc *apisv1alpha1.ProviderConfig) ([]Option, error) {
+	base, err := BaseURL(pc.Spec.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	opts := []Option{
+		WithBaseURL(base),
+		WithRetryPolicy(Retry(pc.Spec.Retry)),
+		WithProviderConfig(pc.GetName()),
+		WithRateLimitOwner(string(pc.GetUID())),
+	}
+	if rl := pc.Spec.RateLimit; rl != nil {
+		var r RateLimit
+		if rl.RequestsPerSecond != nil {
+			r.RequestsPerSecond = float64(*rl.RequestsPerSecond)
+		}
+		if rl.Burst != nil {
+			r.Burst = *rl.Burst
+		}
+		opts = append(opts, WithRateLimit(r))
+	}
+	if pc.Spec.ClusterInventoryTTL != nil {
+		opts = append(opts, WithClusterInventoryTTL(pc.Spec.ClusterInventoryTTL.Duration))
+	}
+	t, err := transportOptions(pc.Spec.Transport)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, t...), nil
+}
+
+// TLSVersions maps the TLS versions accepted by a ProviderConfig to their
+// crypto/tls identifiers.
+var TLSVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// transportOptions returns the client options configured by the supplied
+// transport configuration, except for its CA bundle which is read by
+// ProviderConfigCABundle.
+func transportOptions(tc *apisv1alpha1.TransportConfig) ([]Option, error) {
+	if tc == nil {
+		return nil, nil
+	}
+	var opts []Option
+	if tc.ProxyURL != "" {
+		u, err := url.Parse(tc.ProxyURL)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot parse proxy URL %q", tc.ProxyURL)
+		}
+		opts = append(opts, WithProxy(u))
+	}
+	if tc.MinTLSVersion != "" {
+		v, ok := TLSVersions[tc.MinTLSVersion]
+		if !ok {
+			return nil, errors.Errorf("unknown TLS version %q", tc.MinTLSVersion)
+		}
+		opts = append(opts, WithMinTLSVersion(v))
+	}
+	if tc.Timeout != nil {
+		opts = append(opts, WithTimeout(tc.Timeout.Duration))
+	}
+	return opts, nil
+}
+
+// APIKeys are the API keys a ProviderConfig authenticates with.
+type APIKeys struct {
+	// Primary is the key of the ProviderConfig's credentials.
+	Primary []byte
+
+	// Secondary is the key of the ProviderConfig's secondary credentials,
+	// if any.
+	Secondary []byte
+}
+
+// Options returns the client options that authenticate with the secondary
+// key whenever Komodor rejects the primary one. The primary key is passed to
+// NewClient.
+func (k APIKeys) Options() []Option {
+	if len(k.Secondary) == 0 {
+		return nil
+	}
+	return []Option{WithSecondaryAPIKey(string(k.Secondary))}
+}
+
+// ProviderConfigAPIKeys reads the API keys of the supplied ProviderConfig.
+func ProviderConfigAPIKeys(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig) (APIKeys, error) {
+	cd := pc.Spec.Credentials
+	primary, err := resource.CommonCredentialExtractor(ctx, cd.Source, kube, cd.CommonCredentialSelectors)
+	if err != nil {
+		return APIKeys{}, err
+	}
+	keys := APIKeys{Primary: primary}
+	if cd := pc.Spec.SecondaryCredentials; cd != nil {
+		if keys.Secondary, err = resource.CommonCredentialExtractor(ctx, cd.Source, kube, cd.CommonCredentialSelectors); err != nil {
+			return APIKeys{}, errors.Wrap(err, "cannot read secondary credentials")
+		}
+	}
+	return keys, nil
+}
+
+// ProviderConfigCABundle reads the CA bundle of the supplied ProviderConfig.
+// It returns nil if the ProviderConfig configures none.
+func ProviderConfigCABundle(ctx context.Context, kube client.Reader, pc *apisv1alpha1.ProviderConfig) ([]byte, error) {
+	t := pc.Spec.Transport
+	if t == nil || t.CABundle == nil {
+		return nil, nil
+	}
+
+	var pem []byte
+	switch ref := t.CABundle; {
+	case ref.SecretRef != nil:
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.SecretRef.Namespace, Name: ref.SecretRef.Name}, s); err != nil {
+			return nil, errors.Wrapf(err, "cannot get CA bundle Secret %s/%s", ref.SecretRef.Namespace, ref.SecretRef.Name)
+		}
+		pem = s.Data[ref.SecretRef.Key]
+	case ref.ConfigMapRef != nil:
+		cm := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.ConfigMapRef.Namespace, Name: ref.ConfigMapRef.Name}, cm); err != nil {
+			return nil, errors.Wrapf(err, "cannot get CA bundle ConfigMap %s/%s", ref.ConfigMapRef.Namespace, ref.ConfigMapRef.Name)
+		}
+		pem = []byte(cm.Data[ref.ConfigMapRef.Key])
+	default:
+		return nil, nil
+	}
+	if err := ValidateCABundle(pem); err != nil {
+		return nil, err
+	}
+	return pem, nil
+}
+
+// NewProviderConfigClient builds a Komodor client configured by the supplied
+// ProviderConfig, authenticated with the supplied API keys and trusting the
+// supplied CA bundle. The supplied options apply after the ProviderConfig's.
+func NewProviderConfigClient(pc *apisv1alpha1.ProviderConfig, keys APIKeys, caBundle []byte, opts ...Option) (*Client, error) {
+	o, err := ProviderConfigOptions(pc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot configure Komodor client")
+	}
+	o = append(o, keys.Options()...)
+	if caBundle != nil {
+		o = append(o, WithCABundle(caBundle))
+	}
+	return NewClient(string(keys.Primary), append(o, opts...)...), nil
+}
+
+// ProviderConfigClient returns a Komodor client for the supplied
+// ProviderConfig, reading its API keys and CA bundle. A non-nil cache reuses
+// the client for as long as the ProviderConfig and what it resolves to are
+// unchanged.
+func ProviderConfigClient(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig, cache *ClientCache, opts ...Option) (*Client, error) {
+	keys, err := ProviderConfigAPIKeys(ctx, kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get credentials")
+	}
+	caBundle, err := ProviderConfigCABundle(ctx, kube, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get CA bundle")
+	}
+
+	build := func() (*Client, error) {
+		return NewProviderConfigClient(pc, keys, caBundle, opts...)
+	}
+	if cache == nil {
+		return build()
+	}
+	return cache.Get(pc, keys, caBundle, build)
+}
+
+// ProviderConfigsReferencingSecret returns the ProviderConfigs that read
+// their credentials, secondary credentials or CA bundle from the supplied
+// Secret.
+func ProviderConfigsReferencingSecret(ctx context.Context, kube client.Reader, s client.Object) ([]apisv1alpha1.ProviderConfig, error) {
+	l := &apisv1alpha1.ProviderConfigList{}
+	if err := kube.List(ctx, l); err != nil {
+		return nil, err
+	}
+	var pcs []apisv1alpha1.ProviderConfig
+	for _, pc := range l.Items {
+		if pc.ReferencesSecret(s.GetNamespace(), s.GetName()) {
+			pcs = append(pcs, pc)
+		}
+	}
+	return pcs, nil
+}
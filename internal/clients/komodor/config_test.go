@@ -0,0 +1,288 @@
+package komodor
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apisv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
+)
+
+func TestBaseURL(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		ec      *apisv1alpha1.EndpointConfig
+		want    string
+		wantErr bool
+	}{
+		"NoEndpoint": {
+			reason: "A ProviderConfig without an endpoint should use the US endpoint.",
+			want:   DefaultBaseURL,
+		},
+		"EmptyEndpoint": {
+			reason: "An endpoint that selects neither a region nor a URL should use the US endpoint.",
+			ec:     &apisv1alpha1.EndpointConfig{},
+			want:   DefaultBaseURL,
+		},
+		"RegionEU": {
+			reason: "The EU region should select the EU endpoint.",
+			ec:     &apisv1alpha1.EndpointConfig{Region: "EU"},
+			want:   "https://api.eu.komodor.com",
+		},
+		"URLOverridesRegion": {
+			reason: "An explicit URL should win over a region.",
+			ec:     &apisv1alpha1.EndpointConfig{Region: "EU", URL: "https://komodor.example.org/api"},
+			want:   "https://komodor.example.org/api",
+		},
+		"UnknownRegion": {
+			reason:  "A region Komodor does not serve should be an error.",
+			ec:      &apisv1alpha1.EndpointConfig{Region: "APAC"},
+			wantErr: true,
+		},
+		"MalformedURL": {
+			reason:  "A URL that cannot be parsed should be an error.",
+			ec:      &apisv1alpha1.EndpointConfig{URL: "https://komodor.example.org/%zz"},
+			wantErr: true,
+		},
+		"RelativeURL": {
+			reason:  "A URL without a scheme and host should be an error.",
+			ec:      &apisv1alpha1.EndpointConfig{URL: "/api"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := BaseURL(tc.ec)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("\n%s\nBaseURL(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.String()); diff != "" {
+				t.Errorf("\n%s\nBaseURL(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigOptions(t *testing.T) {
+	// settings are the parts of a Client a ProviderConfig configures.
+	type settings struct {
+		baseURL        string
+		retryPolicy    RetryPolicy
+		providerConfig string
+		rateLimit      RateLimit
+		inventoryTTL   time.Duration
+		proxyURL       string
+		minTLSVersion  uint16
+		timeout        time.Duration
+	}
+	defaults := settings{
+		baseURL:        DefaultBaseURL,
+		retryPolicy:    DefaultRetryPolicy,
+		providerConfig: "default",
+	}
+
+	cases := map[string]struct {
+		reason  string
+		spec    apisv1alpha1.ProviderConfigSpec
+		want    settings
+		wantErr bool
+	}{
+		"Defaults": {
+			reason: "An empty ProviderConfig should only name itself and use the default endpoint and retries.",
+			want:   defaults,
+		},
+		"Configured": {
+			reason: "Every setting of a ProviderConfig should configure the client.",
+			spec: apisv1alpha1.ProviderConfigSpec{
+				Endpoint: &apisv1alpha1.EndpointConfig{Region: "EU"},
+				Retry: &apisv1alpha1.RetryConfig{
+					MaxRetries:     ptr.To(1),
+					InitialBackoff: &metav1.Duration{Duration: time.Second},
+				},
+				RateLimit:           &apisv1alpha1.RateLimitConfig{RequestsPerSecond: ptr.To(5), Burst: ptr.To(10)},
+				ClusterInventoryTTL: &metav1.Duration{Duration: time.Minute},
+				Transport: &apisv1alpha1.TransportConfig{
+					ProxyURL:      "http://proxy.example.org:3128",
+					MinTLSVersion: "1.3",
+					Timeout:       &metav1.Duration{Duration: 10 * time.Second},
+				},
+			},
+			want: settings{
+				baseURL: "https://api.eu.komodor.com",
+				retryPolicy: RetryPolicy{
+					MaxRetries:     1,
+					InitialBackoff: time.Second,
+					MaxBackoff:     DefaultRetryPolicy.MaxBackoff,
+					MaxElapsed:     DefaultRetryPolicy.MaxElapsed,
+				},
+				providerConfig: "default",
+				rateLimit:      RateLimit{RequestsPerSecond: 5, Burst: 10},
+				inventoryTTL:   time.Minute,
+				proxyURL:       "http://proxy.example.org:3128",
+				minTLSVersion:  tls.VersionTLS13,
+				timeout:        10 * time.Second,
+			},
+		},
+		"InvalidEndpoint": {
+			reason:  "An endpoint that selects an unknown region should be an error.",
+			spec:    apisv1alpha1.ProviderConfigSpec{Endpoint: &apisv1alpha1.EndpointConfig{Region: "APAC"}},
+			wantErr: true,
+		},
+		"InvalidProxyURL": {
+			reason:  "A proxy URL that cannot be parsed should be an error.",
+			spec:    apisv1alpha1.ProviderConfigSpec{Transport: &apisv1alpha1.TransportConfig{ProxyURL: "http://proxy.example.org/%zz"}},
+			wantErr: true,
+		},
+		"UnknownTLSVersion": {
+			reason:  "A TLS version the provider does not know should be an error.",
+			spec:    apisv1alpha1.ProviderConfigSpec{Transport: &apisv1alpha1.TransportConfig{MinTLSVersion: "1.1"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "default"}, Spec: tc.spec}
+			opts, err := ProviderConfigOptions(pc)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("\n%s\nProviderConfigOptions(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			c := &Client{}
+			for _, o := range opts {
+				o(c)
+			}
+			got := settings{
+				retryPolicy:    c.retryPolicy,
+				providerConfig: c.providerConfig,
+				rateLimit:      c.rateLimit,
+				inventoryTTL:   c.inventoryTTL,
+				minTLSVersion:  c.minTLSVersion,
+				timeout:        c.timeout,
+			}
+			if c.baseURL != nil {
+				got.baseURL = c.baseURL.String()
+			}
+			if c.proxyURL != nil {
+				got.proxyURL = c.proxyURL.String()
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(settings{})); diff != "" {
+				t.Errorf("\n%s\nProviderConfigOptions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigClient(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	pc := func(ec *apisv1alpha1.EndpointConfig) *apisv1alpha1.ProviderConfig {
+		return &apisv1alpha1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", UID: "pc-uid", Generation: 1},
+			Spec: apisv1alpha1.ProviderConfigSpec{
+				Credentials: apisv1alpha1.ProviderCredentials{
+					Source: xpv1.CredentialsSourceSecret,
+					CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+						SecretRef: &xpv1.SecretKeySelector{
+							SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "komodor"},
+							Key:             "apiKey",
+						},
+					},
+				},
+				Endpoint: ec,
+			},
+		}
+	}
+	get := func(err error) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if err != nil {
+				return err
+			}
+			obj.(*corev1.Secret).Data = map[string][]byte{"apiKey": []byte("s3cr3t")}
+			return nil
+		}
+	}
+
+	type want struct {
+		baseURL string
+		reused  bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		pc     *apisv1alpha1.ProviderConfig
+		get    test.MockGetFn
+		cache  *ClientCache
+		want   want
+	}{
+		"Cached": {
+			reason: "A cache should reuse the client of an unchanged ProviderConfig.",
+			pc:     pc(nil),
+			get:    get(nil),
+			cache:  NewClientCache(),
+			want:   want{baseURL: DefaultBaseURL, reused: true},
+		},
+		"Uncached": {
+			reason: "Without a cache every call should build a new client.",
+			pc:     pc(&apisv1alpha1.EndpointConfig{Region: "EU"}),
+			get:    get(nil),
+			want:   want{baseURL: RegionBaseURLs["EU"]},
+		},
+		"CredentialsUnreadable": {
+			reason: "Credentials that cannot be read should be returned as an error.",
+			pc:     pc(nil),
+			get:    get(errBoom),
+			cache:  NewClientCache(),
+			want:   want{err: errors.Wrap(errors.Wrap(errBoom, "cannot get credentials secret"), "cannot get credentials")},
+		},
+		"InvalidEndpoint": {
+			reason: "A ProviderConfig that selects an unknown region should be returned as an error.",
+			pc:     pc(&apisv1alpha1.EndpointConfig{Region: "APAC"}),
+			get:    get(nil),
+			cache:  NewClientCache(),
+			want:   want{err: errors.Wrap(errors.Errorf("unknown Komodor region %q", "APAC"), "cannot configure Komodor client")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: tc.get}
+			first, err := ProviderConfigClient(context.Background(), kube, tc.pc, tc.cache)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nProviderConfigClient(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			second, err := ProviderConfigClient(context.Background(), kube, tc.pc, tc.cache)
+			if err != nil {
+				t.Fatalf("\n%s\nProviderConfigClient(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.baseURL, first.baseURL.String()); diff != "" {
+				t.Errorf("\n%s\nProviderConfigClient(...): -want base URL, +got base URL:\n%s", tc.reason, diff)
+			}
+			if reused := first == second; reused != tc.want.reused {
+				t.Errorf("\n%s\nProviderConfigClient(...): want reused %t, got %t", tc.reason, tc.want.reused, reused)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	requestIDHeader = "X-Request-Id"
+
+	// maxErrorBody bounds how much of an error response body is read.
+	maxErrorBody = 64 << 10
+
+	// maxRawMessage bounds how much of a non-JSON error body is kept as the
+	// error message.
+	maxRawMessage = 512
+)
+
+// APIError is an unsuccessful response from the Komodor API.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Status is the HTTP status line of the response, e.g. "404 Not Found".
+	Status string
+
+	// Code is the Komodor error code, if the response body carried one.
+	Code string
+
+	// Message is the Komodor error message, if the response body carried
+	// one.
+	Message string
+
+	// RequestID identifies the request to Komodor support.
+	RequestID string
+
+	// Retryable is true if the request may succeed when repeated.
+	Retryable bool
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "komodor API returned %s", e.Status)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " [request ID %s]", e.RequestID)
+	}
+	return b.String()
+}
+
+// errorBody is the error payload returned by the Komodor API. The message is
+// either a string or, for validation errors, a list of strings.
+type errorBody struct {
+	Code      string          `json:"code"`
+	Error     string          `json:"error"`
+	Message   json.RawMessage `json:"message"`
+	RequestID string          `json:"requestId"`
+}
+
+// newAPIError builds an APIError from an unsuccessful response. It consumes
+// but does not close the response body.
+func newAPIError(resp *http.Response) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Retryable:  isRetryableStatus(resp.StatusCode),
+	}
+	if e.Status == "" {
+		e.Status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil || len(body) == 0 {
+		return e
+	}
+
+	var eb errorBody
+	if err := json.Unmarshal(body, &eb); err != nil {
+		e.Message = strings.TrimSpace(string(body[:min(len(body), maxRawMessage)]))
+		return e
+	}
+	e.Code = eb.Code
+	if e.Code == "" {
+		e.Code = eb.Error
+	}
+	e.Message = decodeMessage(eb.Message)
+	if e.RequestID == "" {
+		e.RequestID = eb.RequestID
+	}
+	return e
+}
+
+// decodeMessage flattens a string or list-of-strings error message.
+func decodeMessage(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var l []string
+	if err := json.Unmarshal(raw, &l); err == nil {
+		return strings.Join(l, "; ")
+	}
+	return string(raw)
+}
+
+// isRetryableStatus returns true for statuses that indicate a transient
+// failure on the Komodor side.
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// AsAPIError returns the APIError wrapped by err, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var ae *APIError
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return nil, false
+	}
+	if !errors.As(err, &ae) {
+		return nil, false
+	}
+	return ae, true
+}
+
+func hasStatus(err error, code int) bool {
+	ae, ok := AsAPIError(err)
+	return ok && ae.StatusCode == code
+}
+
+// IsNotFound returns true if the error is a 404 Not Found from the Komodor API.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsBadRequest returns true if the error is a 400 Bad Request from the
+// Komodor API.
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsUnauthorized returns true if the error is a 401 Unauthorized from the
+// Komodor API.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden returns true if the error is a 403 Forbidden from the Komodor
+// API.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict returns true if the error is a 409 Conflict from the Komodor API.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited returns true if the error is a 429 Too Many Requests from the
+// Komodor API.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsRetryable returns true if the error is a Komodor API error that may
+// succeed when the request is repeated.
+func IsRetryable(err error) bool {
+	ae, ok := AsAPIError(err)
+	return ok && ae.Retryable
+}
@@ -0,0 +1,112 @@
+package komodor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAPIError(t *testing.T) {
+	type want struct {
+		err      *APIError
+		notFound bool
+		conflict bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		status int
+		header map[string]string
+		body   string
+		want   want
+	}{
+		"NotFoundWithMessage": {
+			reason: "A JSON error body should populate the code and message.",
+			status: http.StatusNotFound,
+			header: map[string]string{requestIDHeader: "req-1"},
+			body:   `{"code":"MONITOR_NOT_FOUND","message":"monitor does not exist"}`,
+			want: want{
+				err: &APIError{
+					StatusCode: http.StatusNotFound,
+					Status:     "404 Not Found",
+					Code:       "MONITOR_NOT_FOUND",
+					Message:    "monitor does not exist",
+					RequestID:  "req-1",
+				},
+				notFound: true,
+			},
+		},
+		"ValidationMessages": {
+			reason: "A list of validation messages should be joined.",
+			status: http.StatusBadRequest,
+			body:   `{"error":"Bad Request","message":["name must be a string","type is invalid"],"requestId":"req-2"}`,
+			want: want{
+				err: &APIError{
+					StatusCode: http.StatusBadRequest,
+					Status:     "400 Bad Request",
+					Code:       "Bad Request",
+					Message:    "name must be a string; type is invalid",
+					RequestID:  "req-2",
+				},
+			},
+		},
+		"RateLimitedPlainText": {
+			reason: "A non-JSON body should be kept as the message and 429 is retryable.",
+			status: http.StatusTooManyRequests,
+			body:   "slow down\n",
+			want: want{
+				err: &APIError{
+					StatusCode: http.StatusTooManyRequests,
+					Status:     "429 Too Many Requests",
+					Message:    "slow down",
+					Retryable:  true,
+				},
+			},
+		},
+		"Conflict": {
+			reason: "A 409 should be reported as a conflict.",
+			status: http.StatusConflict,
+			want: want{
+				err: &APIError{
+					StatusCode: http.StatusConflict,
+					Status:     "409 Conflict",
+				},
+				conflict: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for k, v := range tc.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			base, _ := url.Parse(srv.URL)
+			_, err := NewClient("key", WithBaseURL(base), WithRetryPolicy(RetryPolicy{})).GetMonitor(context.Background(), "id")
+
+			ae, ok := AsAPIError(err)
+			if !ok {
+				t.Fatalf("\n%s\nGetMonitor(...): want *APIError, got %T: %v", tc.reason, err, err)
+			}
+			if diff := cmp.Diff(tc.want.err, ae); diff != "" {
+				t.Errorf("\n%s\nGetMonitor(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if got := IsNotFound(err); got != tc.want.notFound {
+				t.Errorf("\n%s\nIsNotFound(...): want %t, got %t", tc.reason, tc.want.notFound, got)
+			}
+			if got := IsConflict(err); got != tc.want.conflict {
+				t.Errorf("\n%s\nIsConflict(...): want %t, got %t", tc.reason, tc.want.conflict, got)
+			}
+		})
+	}
+}
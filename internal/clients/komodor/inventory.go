@@ -0,0 +1,122 @@
+package komodor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// DefaultClusterInventoryTTL is how long a cached cluster inventory is used
+// when a ProviderConfig does not configure a TTL.
+const DefaultClusterInventoryTTL = time.Minute
+
+// A ClusterInventory caches the Komodor cluster inventory of each
+// credential. It is safe for concurrent use and meant to be shared by all
+// clients of a controller, so validating many cluster names costs a single
+// list of the inventory per TTL.
+type ClusterInventory struct {
+	mu      sync.Mutex
+	entries map[string]*inventoryEntry
+
+	now func() time.Time
+}
+
+type inventoryEntry struct {
+	// mu serializes refreshes so concurrent callers share one list call.
+	mu        sync.Mutex
+	clusters  []Cluster
+	fetchedAt time.Time
+	valid     bool
+}
+
+// NewClusterInventory returns an empty cluster inventory cache.
+func NewClusterInventory() *ClusterInventory {
+	return &ClusterInventory{entries: map[string]*inventoryEntry{}, now: time.Now}
+}
+
+func (i *ClusterInventory) entry(key string) *inventoryEntry {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	e, ok := i.entries[key]
+	if !ok {
+		e = &inventoryEntry{}
+		i.entries[key] = e
+	}
+	return e
+}
+
+// Get returns the cached inventory of the supplied credential key, calling
+// list to refresh it when it is missing or older than ttl.
+func (i *ClusterInventory) Get(ctx context.Context, key string, ttl time.Duration, list func(context.Context) ([]Cluster, error)) ([]Cluster, error) {
+	e := i.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.valid && i.now().Sub(e.fetchedAt) < ttl {
+		return e.clusters, nil
+	}
+	clusters, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e.clusters, e.fetchedAt, e.valid = clusters, i.now(), true
+	return clusters, nil
+}
+
+// Set replaces the cached inventory of the supplied credential key.
+func (i *ClusterInventory) Set(key string, clusters []Cluster) {
+	e := i.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.clusters, e.fetchedAt, e.valid = clusters, i.now(), true
+}
+
+// Invalidate drops the cached inventory of the supplied credential key. The
+// next Get lists the inventory again.
+func (i *ClusterInventory) Invalidate(key string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.entries, key)
+}
+
+// WithClusterInventory makes the client validate cluster names against the
+// supplied shared inventory cache.
+func WithClusterInventory(inv *ClusterInventory) Option {
+	return func(c *Client) {
+		c.inventory = inv
+	}
+}
+
+// WithClusterInventoryTTL sets how long the client trusts a cached cluster
+// inventory. Zero disables caching.
+func WithClusterInventoryTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.inventoryTTL = ttl
+	}
+}
+
+// credentialKey identifies the Komodor account and endpoint of the client
+// without retaining the API key itself.
+func (c *Client) credentialKey() string {
+	h := sha256.Sum256([]byte(c.baseURL.String() + "\x00" + c.apiKey))
+	return hex.EncodeToString(h[:])
+}
+
+// CachedClusters returns the cluster inventory, served from the shared
+// inventory cache when one is configured.
+func (c *Client) CachedClusters(ctx context.Context) ([]Cluster, error) {
+	if c.inventory == nil || c.inventoryTTL <= 0 {
+		return c.listClusters(ctx)
+	}
+	return c.inventory.Get(ctx, c.credentialKey(), c.inventoryTTL, c.listClusters)
+}
+
+// InvalidateClusters drops the cached cluster inventory of the client's
+// credential.
+func (c *Client) InvalidateClusters() {
+	if c.inventory != nil {
+		c.inventory.Invalidate(c.credentialKey())
+	}
+}
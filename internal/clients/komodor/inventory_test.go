@@ -0,0 +1,73 @@
+package komodor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClusterInventory(t *testing.T) {
+	type step struct {
+		advance    time.Duration
+		invalidate bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		ttl    time.Duration
+		steps  []step
+		want   int
+	}{
+		"FreshEntryReused": {
+			reason: "Lookups within the TTL should share a single list call.",
+			ttl:    time.Minute,
+			steps:  []step{{}, {advance: 10 * time.Second}, {advance: 10 * time.Second}},
+			want:   1,
+		},
+		"ExpiredEntryRefreshed": {
+			reason: "A lookup after the TTL should list the inventory again.",
+			ttl:    time.Minute,
+			steps:  []step{{}, {advance: 2 * time.Minute}},
+			want:   2,
+		},
+		"InvalidatedEntryRefreshed": {
+			reason: "A lookup after invalidation should list the inventory again.",
+			ttl:    time.Minute,
+			steps:  []step{{}, {invalidate: true}},
+			want:   2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := time.Unix(0, 0)
+			inv := NewClusterInventory()
+			inv.now = func() time.Time { return now }
+
+			calls := 0
+			list := func(context.Context) ([]Cluster, error) {
+				calls++
+				return []Cluster{{Name: "prod"}}, nil
+			}
+
+			for _, s := range tc.steps {
+				now = now.Add(s.advance)
+				if s.invalidate {
+					inv.Invalidate("key")
+				}
+				got, err := inv.Get(context.Background(), "key", tc.ttl, list)
+				if err != nil {
+					t.Fatalf("\n%s\nGet(...): unexpected error: %v", tc.reason, err)
+				}
+				if diff := cmp.Diff([]Cluster{{Name: "prod"}}, got); diff != "" {
+					t.Errorf("\n%s\nGet(...): -want, +got:\n%s\n", tc.reason, diff)
+				}
+			}
+			if calls != tc.want {
+				t.Errorf("\n%s\nGet(...): want %d list calls, got %d", tc.reason, tc.want, calls)
+			}
+		})
+	}
+}
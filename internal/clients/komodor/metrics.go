@@ -0,0 +1,130 @@
+package komodor
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "komodor_api"
+
+var retriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "retries_total",
+	Help:      "Number of Komodor API requests that were retried, by HTTP method and retry reason.",
+}, []string{"method", "reason"})
+
+var rateLimiterWaitSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: metricsNamespace,
+	Name:      "rate_limiter_wait_seconds",
+	Help:      "Time Komodor API requests spent waiting for the client-side rate limiter.",
+	Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+})
+
+var requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metricsNamespace,
+	Name:      "requests_total",
+	Help:      "Number of Komodor API requests, by operation, HTTP status class and ProviderConfig.",
+}, []string{"operation", "status_class", "provider_config"})
+
+var requestDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metricsNamespace,
+	Name:      "request_duration_seconds",
+	Help:      "Time until Komodor answered a request, including retries and rate limiting, by operation, HTTP status class and ProviderConfig.",
+	Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+}, []string{"operation", "status_class", "provider_config"})
+
+var inFlightRequests = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: metricsNamespace,
+	Name:      "in_flight_requests",
+	Help:      "Number of Komodor API requests awaiting an answer, by operation and ProviderConfig.",
+}, []string{"operation", "provider_config"})
+
+// Collectors returns the Prometheus collectors of the Komodor client. They
+// must be registered with a registry to be exported.
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{retriesTotal, rateLimiterWaitSeconds, requestsTotal, requestDurationSeconds, inFlightRequests}
+}
+
+// WithProviderConfig sets the name of the ProviderConfig the client was
+// built from, which labels its metrics.
+func WithProviderConfig(name string) Option {
+	return func(c *Client) {
+		c.providerConfig = name
+	}
+}
+
+type operationKey struct{}
+
+// withOperation returns a context that names the client operation of the
+// requests it carries.
+func withOperation(ctx context.Context, method, path string) context.Context {
+	return context.WithValue(ctx, operationKey{}, operation(method, path))
+}
+
+// operation returns the name of the client operation that sends a request
+// with the supplied method to the supplied path.
+func operation(method, path string) string {
+	switch {
+	case path == monitorsPath && method == http.MethodGet:
+		return "ListMonitors"
+	case path == monitorsPath && method == http.MethodPost:
+		return "CreateMonitor"
+	case strings.HasPrefix(path, monitorsPath+"/") && method == http.MethodGet:
+		return "GetMonitor"
+	case strings.HasPrefix(path, monitorsPath+"/") && method == http.MethodPatch:
+		return "UpdateMonitor"
+	case strings.HasPrefix(path, monitorsPath+"/") && method == http.MethodDelete:
+		return "DeleteMonitor"
+	case path == clustersPath && method == http.MethodGet:
+		return "ListClusters"
+	}
+	return "Unknown"
+}
+
+// requestOperation returns the name of the client operation that sent the
+// supplied request.
+func requestOperation(req *http.Request) string {
+	if op, ok := req.Context().Value(operationKey{}).(string); ok {
+		return op
+	}
+	return "Unknown"
+}
+
+// statusClass returns the class of the supplied response's status, e.g.
+// 2xx, or "error" if no response was received.
+func statusClass(resp *http.Response, err error) string {
+	if err != nil {
+		return "error"
+	}
+	return strconv.Itoa(resp.StatusCode/100) + "xx"
+}
+
+// metricsTransport is an http.RoundTripper that records the number, latency
+// and concurrency of the requests of one ProviderConfig's client. It sits
+// above the retrying transport, so a request counts once however often it
+// is attempted.
+type metricsTransport struct {
+	next           http.RoundTripper
+	providerConfig string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op := requestOperation(req)
+
+	inFlight := inFlightRequests.WithLabelValues(op, t.providerConfig)
+	inFlight.Inc()
+	defer inFlight.Dec()
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	class := statusClass(resp, err)
+	requestsTotal.WithLabelValues(op, class, t.providerConfig).Inc()
+	requestDurationSeconds.WithLabelValues(op, class, t.providerConfig).Observe(time.Since(start).Seconds())
+	return resp, err
+}
@@ -0,0 +1,90 @@
+package komodor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// write returns the current value of the supplied metric.
+func write(t *testing.T, m prometheus.Metric) *dto.Metric {
+	t.Helper()
+	out := &dto.Metric{}
+	if err := m.Write(out); err != nil {
+		t.Fatalf("Write(...): unexpected error: %v", err)
+	}
+	return out
+}
+
+func TestOperation(t *testing.T) {
+	cases := map[string]struct {
+		method string
+		path   string
+		want   string
+	}{
+		"ListMonitors":  {method: http.MethodGet, path: monitorsPath, want: "ListMonitors"},
+		"CreateMonitor": {method: http.MethodPost, path: monitorsPath, want: "CreateMonitor"},
+		"GetMonitor":    {method: http.MethodGet, path: monitorsPath + "/m-1", want: "GetMonitor"},
+		"UpdateMonitor": {method: http.MethodPatch, path: monitorsPath + "/m-1", want: "UpdateMonitor"},
+		"DeleteMonitor": {method: http.MethodDelete, path: monitorsPath + "/m-1", want: "DeleteMonitor"},
+		"ListClusters":  {method: http.MethodGet, path: clustersPath, want: "ListClusters"},
+		"Unknown":       {method: http.MethodPut, path: "/api/v2/other", want: "Unknown"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := operation(tc.method, tc.path); got != tc.want {
+				t.Errorf("operation(%q, %q): want %q, got %q", tc.method, tc.path, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMetricsTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	base, _ := url.Parse(srv.URL)
+	c := NewClient("key", WithBaseURL(base), WithProviderConfig("metrics-test"), WithRetryPolicy(RetryPolicy{}))
+	for _, method := range []string{http.MethodGet, http.MethodGet, http.MethodDelete} {
+		resp, err := c.doRequest(context.Background(), method, monitorsPath+"/m-1", nil, nil)
+		if err != nil {
+			t.Fatalf("doRequest(...): unexpected error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	cases := map[string]struct {
+		operation string
+		class     string
+		want      float64
+	}{
+		"Succeeded": {operation: "GetMonitor", class: "2xx", want: 2},
+		"Failed":    {operation: "DeleteMonitor", class: "4xx", want: 1},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := write(t, requestsTotal.WithLabelValues(tc.operation, tc.class, "metrics-test")).GetCounter().GetValue(); got != tc.want {
+				t.Errorf("requests_total: want %v, got %v", tc.want, got)
+			}
+			d := requestDurationSeconds.WithLabelValues(tc.operation, tc.class, "metrics-test").(prometheus.Metric)
+			if got := write(t, d).GetHistogram().GetSampleCount(); got != uint64(tc.want) {
+				t.Errorf("request_duration_seconds: want %v observations, got %d", tc.want, got)
+			}
+			if got := write(t, inFlightRequests.WithLabelValues(tc.operation, "metrics-test")).GetGauge().GetValue(); got != 0 {
+				t.Errorf("in_flight_requests: want 0, got %v", got)
+			}
+		})
+	}
+}
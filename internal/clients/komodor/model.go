@@ -0,0 +1,317 @@
+package komodor
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Monitor is a struct representing a Komodor Real Time Monitor.
+type Monitor struct {
+	ID           string              `json:"id,omitempty"`
+	CreatedAt    string              `json:"createdAt,omitempty"`
+	UpdatedAt    string              `json:"updatedAt,omitempty"`
+	IsDeleted    bool                `json:"isDeleted,omitempty"`
+	Name         string              `json:"name"`
+	Sensors      []Sensor            `json:"sensors,omitempty"`
+	Sinks        *Sinks              `json:"sinks,omitempty"`
+	Active       bool                `json:"active"`
+	Type         string              `json:"type"`
+	Variables    *Variables          `json:"variables,omitempty"`
+	SinksOptions map[string][]string `json:"sinksOptions,omitempty"`
+}
+
+// Extra holds the JSON fields of a Komodor object that the typed model does
+// not know about. They are kept verbatim so that fields added by Komodor
+// survive a read-modify-write round trip.
+type Extra map[string]json.RawMessage
+
+// A Sensor selects the Kubernetes resources a monitor watches.
+type Sensor struct {
+	// Cluster is the name of the Komodor cluster the sensor watches.
+	Cluster string `json:"cluster,omitempty"`
+
+	// Namespaces limits the sensor to these namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Services limits the sensor to these services.
+	Services []string `json:"services,omitempty"`
+
+	// Conditions limits a node sensor to these node conditions.
+	Conditions []string `json:"conditions,omitempty"`
+
+	// Labels limits the sensor to resources with these key:value labels.
+	Labels []string `json:"labels,omitempty"`
+
+	// Exclude removes namespaces or services from the sensor's scope.
+	Exclude *SensorScope `json:"exclude,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+// A SensorScope is a set of namespaces and services.
+type SensorScope struct {
+	Namespaces []string `json:"namespaces,omitempty"`
+	Services   []string `json:"services,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+// Sinks are the destinations a monitor notifies.
+type Sinks struct {
+	// Slack channels to notify.
+	Slack []string `json:"slack,omitempty"`
+
+	// Teams channels to notify.
+	Teams []string `json:"teams,omitempty"`
+
+	// Opsgenie integrations to notify.
+	Opsgenie []string `json:"opsgenie,omitempty"`
+
+	// PagerDuty services to notify.
+	PagerDuty []PagerDutySink `json:"pagerDuty,omitempty"`
+
+	// GenericWebhook integrations to notify.
+	GenericWebhook []string `json:"genericWebhook,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+// A PagerDutySink routes notifications to a PagerDuty service.
+type PagerDutySink struct {
+	Channel              string `json:"channel,omitempty"`
+	IntegrationKey       string `json:"integrationKey,omitempty"`
+	PagerDutyAccountName string `json:"pagerDutyAccountName,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+// Variables tune when a monitor fires. Which variables apply depends on the
+// monitor type.
+type Variables struct {
+	// Duration in seconds a condition must hold before the monitor fires.
+	Duration *int `json:"duration,omitempty"`
+
+	// MinAvailable is the number or percentage of replicas that must be
+	// available.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Categories of failure reasons that trigger the monitor.
+	Categories []string `json:"categories,omitempty"`
+
+	// Reasons that trigger the monitor.
+	Reasons []string `json:"reasons,omitempty"`
+
+	// NodeCreationThreshold ignores nodes younger than this duration.
+	NodeCreationThreshold string `json:"nodeCreationThreshold,omitempty"`
+
+	// CronJobCondition selects whether the first or any failed run fires.
+	CronJobCondition string `json:"cronJobCondition,omitempty"`
+
+	// ResolveAfter in seconds auto-resolves the issue.
+	ResolveAfter *int `json:"resolveAfter,omitempty"`
+
+	// IgnoreAfter in seconds stops tracking the issue.
+	IgnoreAfter *int `json:"ignoreAfter,omitempty"`
+
+	Extra Extra `json:"-"`
+}
+
+// UnmarshalJSON keeps unknown fields in Extra.
+func (s *Sensor) UnmarshalJSON(data []byte) error {
+	type sensor Sensor
+	var v sensor
+	extra, err := unmarshalWithExtra(data, &v)
+	if err != nil {
+		return err
+	}
+	*s = Sensor(v)
+	s.Extra = extra
+	return nil
+}
+
+// MarshalJSON writes Extra back alongside the known fields.
+func (s Sensor) MarshalJSON() ([]byte, error) {
+	type sensor Sensor
+	return marshalWithExtra(sensor(s), s.Extra)
+}
+
+// UnmarshalJSON keeps unknown fields in Extra.
+func (s *SensorScope) UnmarshalJSON(data []byte) error {
+	type scope SensorScope
+	var v scope
+	extra, err := unmarshalWithExtra(data, &v)
+	if err != nil {
+		return err
+	}
+	*s = SensorScope(v)
+	s.Extra = extra
+	return nil
+}
+
+// MarshalJSON writes Extra back alongside the known fields.
+func (s SensorScope) MarshalJSON() ([]byte, error) {
+	type scope SensorScope
+	return marshalWithExtra(scope(s), s.Extra)
+}
+
+// UnmarshalJSON keeps unknown fields in Extra.
+func (s *Sinks) UnmarshalJSON(data []byte) error {
+	type sinks Sinks
+	var v sinks
+	extra, err := unmarshalWithExtra(data, &v)
+	if err != nil {
+		return err
+	}
+	*s = Sinks(v)
+	s.Extra = extra
+	return nil
+}
+
+// MarshalJSON writes Extra back alongside the known fields.
+func (s Sinks) MarshalJSON() ([]byte, error) {
+	type sinks Sinks
+	return marshalWithExtra(sinks(s), s.Extra)
+}
+
+// UnmarshalJSON keeps unknown fields in Extra.
+func (s *PagerDutySink) UnmarshalJSON(data []byte) error {
+	type sink PagerDutySink
+	var v sink
+	extra, err := unmarshalWithExtra(data, &v)
+	if err != nil {
+		return err
+	}
+	*s = PagerDutySink(v)
+	s.Extra = extra
+	return nil
+}
+
+// MarshalJSON writes Extra back alongside the known fields.
+func (s PagerDutySink) MarshalJSON() ([]byte, error) {
+	type sink PagerDutySink
+	return marshalWithExtra(sink(s), s.Extra)
+}
+
+// UnmarshalJSON keeps unknown fields in Extra.
+// Komodor may encode whole numbers as floats, e.g. 30.0, which are decoded
+// as the integers they are.
+func (v *Variables) UnmarshalJSON(data []byte) error {
+	type variables Variables
+	var o variables
+	data, err := integralNumbers(data, "duration", "minAvailable", "resolveAfter", "ignoreAfter")
+	if err != nil {
+		return err
+	}
+	extra, err := unmarshalWithExtra(data, &o)
+	if err != nil {
+		return err
+	}
+	*v = Variables(o)
+	v.Extra = extra
+	return nil
+}
+
+// MarshalJSON writes Extra back alongside the known fields.
+func (v Variables) MarshalJSON() ([]byte, error) {
+	type variables Variables
+	return marshalWithExtra(variables(v), v.Extra)
+}
+
+// unmarshalWithExtra decodes data into v, which must point to a struct
+// without a custom UnmarshalJSON, and returns the fields v does not declare.
+// Unknown values are compacted so equal JSON compares equal.
+func unmarshalWithExtra(data []byte, v any) (Extra, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for _, name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(all, name)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	extra := make(Extra, len(all))
+	for k, raw := range all {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, raw); err != nil {
+			return nil, err
+		}
+		extra[k] = buf.Bytes()
+	}
+	return extra, nil
+}
+
+// marshalWithExtra encodes v, which must be a struct without a custom
+// MarshalJSON, and merges in the supplied unknown fields.
+func marshalWithExtra(v any, extra Extra) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(b, &all); err != nil {
+		return nil, err
+	}
+	for k, raw := range extra {
+		if _, known := all[k]; !known {
+			all[k] = raw
+		}
+	}
+	return json.Marshal(all)
+}
+
+// integralNumbers rewrites the supplied fields of the JSON object data that
+// are whole numbers written as floats, e.g. 30.0 or 3e1, as integers. Other
+// values are left as they are.
+func integralNumbers(data []byte, fields ...string) ([]byte, error) {
+	var all map[string]json.RawMessage
+	if json.Unmarshal(data, &all) != nil || all == nil {
+		// Leave reporting invalid JSON to the decoder.
+		return data, nil
+	}
+	changed := false
+	for _, name := range fields {
+		raw, ok := all[name]
+		if !ok || !bytes.ContainsAny(raw, ".eE") || bytes.HasPrefix(bytes.TrimSpace(raw), []byte(`"`)) {
+			continue
+		}
+		f, err := strconv.ParseFloat(string(bytes.TrimSpace(raw)), 64)
+		if err != nil || f != math.Trunc(f) || math.Abs(f) > math.MaxInt32 {
+			continue
+		}
+		all[name] = json.RawMessage(strconv.FormatInt(int64(f), 10))
+		changed = true
+	}
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(all)
+}
+
+// jsonFieldNames returns the JSON names of the fields of struct type t.
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
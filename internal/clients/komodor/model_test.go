@@ -0,0 +1,93 @@
+package komodor
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestMonitorJSON(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		in     string
+		want   Monitor
+	}{
+		"KnownFields": {
+			reason: "Known sensor, sink and variable fields should decode into typed fields.",
+			in:     `{"name":"m","active":true,"type":"deploy","sensors":[{"cluster":"prod","namespaces":["a"],"exclude":{"services":["s"]}}],"sinks":{"slack":["alerts"],"pagerDuty":[{"channel":"c","integrationKey":"k"}]},"variables":{"duration":30,"minAvailable":"50%"}}`,
+			want: Monitor{
+				Name:   "m",
+				Active: true,
+				Type:   "deploy",
+				Sensors: []Sensor{{
+					Cluster:    "prod",
+					Namespaces: []string{"a"},
+					Exclude:    &SensorScope{Services: []string{"s"}},
+				}},
+				Sinks: &Sinks{
+					Slack:     []string{"alerts"},
+					PagerDuty: []PagerDutySink{{Channel: "c", IntegrationKey: "k"}},
+				},
+				Variables: &Variables{
+					Duration:     ptr.To(30),
+					MinAvailable: ptr.To(intstr.FromString("50%")),
+				},
+			},
+		},
+		"UnknownFields": {
+			reason: "Fields the model does not know should be kept in Extra.",
+			in:     `{"name":"m","active":false,"type":"node","sensors":[{"cluster":"prod","future":{"a": 1}}],"sinks":{"email":["x@y"]},"variables":{"newKnob":true}}`,
+			want: Monitor{
+				Name:    "m",
+				Type:    "node",
+				Sensors: []Sensor{{Cluster: "prod", Extra: Extra{"future": json.RawMessage(`{"a":1}`)}}},
+				Sinks:   &Sinks{Extra: Extra{"email": json.RawMessage(`["x@y"]`)}},
+				Variables: &Variables{
+					Extra: Extra{"newKnob": json.RawMessage(`true`)},
+				},
+			},
+		},
+		"FloatNumbers": {
+			reason: "Whole numbers Komodor encodes as floats should decode as integers.",
+			in:     `{"name":"m","type":"availability","variables":{"duration":30.0,"minAvailable":2e0,"resolveAfter":600.00,"threshold":0.5}}`,
+			want: Monitor{
+				Name: "m",
+				Type: "availability",
+				Variables: &Variables{
+					Duration:     ptr.To(30),
+					MinAvailable: ptr.To(intstr.FromInt32(2)),
+					ResolveAfter: ptr.To(600),
+					Extra:        Extra{"threshold": json.RawMessage(`0.5`)},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got Monitor
+			if err := json.Unmarshal([]byte(tc.in), &got); err != nil {
+				t.Fatalf("\n%s\nUnmarshal(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nUnmarshal(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			// Encoding and decoding again must not lose any field.
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("\n%s\nMarshal(...): %v", tc.reason, err)
+			}
+			var again Monitor
+			if err := json.Unmarshal(b, &again); err != nil {
+				t.Fatalf("\n%s\nUnmarshal(Marshal(...)): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, again); diff != "" {
+				t.Errorf("\n%s\nround trip: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
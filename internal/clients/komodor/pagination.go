@@ -0,0 +1,154 @@
+package komodor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// defaultPageSize is the number of items requested per page.
+	defaultPageSize = 100
+
+	// maxPages guards against a misbehaving API returning an endless chain
+	// of pages.
+	maxPages = 1000
+)
+
+// PageMeta is the pagination metadata Komodor attaches to list responses.
+// Endpoints use either an opaque cursor or page numbers.
+type PageMeta struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	TotalPages int    `json:"totalPages,omitempty"`
+	HasMore    *bool  `json:"hasMore,omitempty"`
+}
+
+// next returns the query selecting the page after the one described by m,
+// or false if m describes the last page.
+func (m PageMeta) next(q url.Values) (url.Values, bool) {
+	n := url.Values{}
+	for k, v := range q {
+		n[k] = v
+	}
+	switch {
+	case m.NextCursor != "":
+		n.Del("page")
+		n.Set("cursor", m.NextCursor)
+		return n, true
+	case m.HasMore != nil && !*m.HasMore:
+		return nil, false
+	case m.Page > 0 && (m.Page < m.TotalPages || (m.HasMore != nil && *m.HasMore)):
+		n.Del("cursor")
+		n.Set("page", strconv.Itoa(m.Page+1))
+		return n, true
+	}
+	return nil, false
+}
+
+// decodePageFn decodes the items and pagination metadata of one page.
+type decodePageFn[T any] func(body []byte) ([]T, PageMeta, error)
+
+// paginate returns an iterator over every item of a paginated list endpoint.
+// Pages are fetched lazily, so stopping the iteration early saves requests.
+// An error ends the iteration.
+func paginate[T any](ctx context.Context, c *Client, path string, decode decodePageFn[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		q := url.Values{"pageSize": []string{strconv.Itoa(defaultPageSize)}}
+		seen := map[string]bool{}
+
+		for range maxPages {
+			items, meta, err := listPage(ctx, c, path, q, decode)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			next, ok := meta.next(q)
+			if !ok || len(items) == 0 {
+				return
+			}
+			if meta.NextCursor != "" {
+				if seen[meta.NextCursor] {
+					yield(zero, fmt.Errorf("komodor API repeated pagination cursor %q", meta.NextCursor))
+					return
+				}
+				seen[meta.NextCursor] = true
+			}
+			q = next
+		}
+		yield(zero, fmt.Errorf("komodor API returned more than %d pages for %s", maxPages, path))
+	}
+}
+
+// listPage fetches and decodes a single page of a list endpoint.
+func listPage[T any](ctx context.Context, c *Client, path string, q url.Values, decode decodePageFn[T]) ([]T, PageMeta, error) {
+	resp, err := c.doRequest(ctx, "GET", path, q, nil)
+	if err != nil {
+		return nil, PageMeta{}, err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			_ = cerr // explicitly ignore
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, PageMeta{}, newAPIError(resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, PageMeta{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return decode(body)
+}
+
+// collect drains a paginated iterator into a slice.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var all []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, item)
+	}
+	return all, nil
+}
+
+// decodeMonitorsPage accepts both a bare array of monitors and an object
+// wrapping them in a data field alongside pagination metadata.
+func decodeMonitorsPage(body []byte) ([]Monitor, PageMeta, error) {
+	var monitors []Monitor
+	if err := json.Unmarshal(body, &monitors); err == nil {
+		return monitors, PageMeta{}, nil
+	}
+
+	var response struct {
+		Data []Monitor `json:"data"`
+		Meta PageMeta  `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, PageMeta{}, fmt.Errorf("failed to unmarshal monitors response: %w, body: %s", err, string(body[:min(len(body), 200)]))
+	}
+	return response.Data, response.Meta, nil
+}
+
+// decodeClustersPage decodes a page of the clusters API.
+func decodeClustersPage(body []byte) ([]Cluster, PageMeta, error) {
+	var response ClustersResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, PageMeta{}, fmt.Errorf("failed to unmarshal clusters response: %w", err)
+	}
+	return response.Data.Clusters, response.Meta, nil
+}
@@ -0,0 +1,103 @@
+package komodor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPagination(t *testing.T) {
+	type want struct {
+		names    []string
+		requests int
+	}
+
+	cases := map[string]struct {
+		reason string
+		path   string
+		pages  map[string]string
+		list   func(c *Client) ([]string, error)
+		want   want
+	}{
+		"ClustersByCursor": {
+			reason: "Cluster pages should be followed by cursor until none is returned.",
+			path:   clustersPath,
+			pages: map[string]string{
+				"":   `{"data":{"clusters":[{"name":"a"},{"name":"b"}]},"meta":{"nextCursor":"c2"}}`,
+				"c2": `{"data":{"clusters":[{"name":"c"}]},"meta":{}}`,
+			},
+			list: func(c *Client) ([]string, error) {
+				cs, err := c.ListClusters(context.Background())
+				names := make([]string, 0, len(cs))
+				for _, cl := range cs {
+					names = append(names, cl.Name)
+				}
+				return names, err
+			},
+			want: want{names: []string{"a", "b", "c"}, requests: 2},
+		},
+		"MonitorsByPage": {
+			reason: "Monitor pages should be followed by page number up to the total.",
+			path:   monitorsPath,
+			pages: map[string]string{
+				"":  `{"data":[{"name":"m1"}],"meta":{"page":1,"totalPages":2}}`,
+				"2": `{"data":[{"name":"m2"}],"meta":{"page":2,"totalPages":2}}`,
+			},
+			list: func(c *Client) ([]string, error) {
+				ms, err := c.ListMonitors(context.Background())
+				names := make([]string, 0, len(ms))
+				for _, m := range ms {
+					names = append(names, m.Name)
+				}
+				return names, err
+			},
+			want: want{names: []string{"m1", "m2"}, requests: 2},
+		},
+		"MonitorsUnpaginated": {
+			reason: "A bare array of monitors is a single, complete page.",
+			path:   monitorsPath,
+			pages: map[string]string{
+				"": `[{"name":"m1"},{"name":"m2"}]`,
+			},
+			list: func(c *Client) ([]string, error) {
+				ms, err := c.ListMonitors(context.Background())
+				names := make([]string, 0, len(ms))
+				for _, m := range ms {
+					names = append(names, m.Name)
+				}
+				return names, err
+			},
+			want: want{names: []string{"m1", "m2"}, requests: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != tc.path {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				key := r.URL.Query().Get("cursor") + r.URL.Query().Get("page")
+				_, _ = w.Write([]byte(tc.pages[key]))
+			}))
+			defer srv.Close()
+
+			base, _ := url.Parse(srv.URL)
+			names, err := tc.list(NewClient("key", WithBaseURL(base)))
+			if err != nil {
+				t.Fatalf("\n%s\nunexpected error: %v", tc.reason, err)
+			}
+			got := want{names: names, requests: requests}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\n-want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
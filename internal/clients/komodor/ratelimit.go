@@ -0,0 +1,175 @@
+package komodor
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// A RateLimit is the outbound request budget of one Komodor API key.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests.
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that may be sent at once after a
+	// quiet period.
+	Burst int
+}
+
+// DefaultRateLimit is used for API keys whose ProviderConfig does not
+// configure a rate limit, unless overridden by SetDefaultRateLimit.
+var DefaultRateLimit = RateLimit{RequestsPerSecond: 5, Burst: 10}
+
+// Limiters hands out a token bucket per API key, so ProviderConfigs pointing
+// at different Komodor accounts get independent budgets while all clients
+// sharing a key share one. Each owner, usually a ProviderConfig, records its
+// budget for one key at a time, and a bucket allows the smallest budget
+// recorded for its key. It is safe for concurrent use.
+type Limiters struct {
+	mu       sync.Mutex
+	defaults RateLimit
+	limiters map[string]*keyLimiter
+	owners   map[string]string
+}
+
+// A keyLimiter is the token bucket of an API key and the budgets its owners
+// recorded for it.
+type keyLimiter struct {
+	limiter *rate.Limiter
+	budgets map[string]RateLimit
+}
+
+// NewLimiters returns a limiter registry using the supplied default budget.
+func NewLimiters(defaults RateLimit) *Limiters {
+	return &Limiters{defaults: defaults, limiters: map[string]*keyLimiter{}, owners: map[string]string{}}
+}
+
+// sharedLimiters is used by every client that is not given its own
+// registry, so budgets hold across controllers.
+var sharedLimiters = NewLimiters(DefaultRateLimit)
+
+// SetDefaultRateLimit changes the budget of API keys whose ProviderConfig
+// does not configure one. It is meant to be called once at startup.
+func SetDefaultRateLimit(rl RateLimit) {
+	sharedLimiters.mu.Lock()
+	defer sharedLimiters.mu.Unlock()
+	sharedLimiters.defaults = rl
+}
+
+// Get returns the limiter of the supplied key, creating it if needed, and
+// records the supplied owner's budget for it. A non-zero rl overrides the
+// default budget. An owner that recorded a budget for another key releases
+// it.
+func (l *Limiters) Get(key, owner string, rl RateLimit) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if rl.RequestsPerSecond <= 0 {
+		rl.RequestsPerSecond = l.defaults.RequestsPerSecond
+	}
+	if rl.Burst <= 0 {
+		rl.Burst = l.defaults.Burst
+	}
+	if k, ok := l.owners[owner]; ok && k != key {
+		l.release(owner)
+	}
+
+	kl, ok := l.limiters[key]
+	if !ok {
+		kl = &keyLimiter{limiter: rate.NewLimiter(rate.Inf, 1), budgets: map[string]RateLimit{}}
+		l.limiters[key] = kl
+	}
+	kl.budgets[owner] = rl
+	l.owners[owner] = key
+	kl.apply()
+	return kl.limiter
+}
+
+// Release drops the budget the supplied owner recorded, and the limiter of
+// its key once no owner is left.
+func (l *Limiters) Release(owner string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.release(owner)
+}
+
+func (l *Limiters) release(owner string) {
+	key, ok := l.owners[owner]
+	if !ok {
+		return
+	}
+	delete(l.owners, owner)
+	kl := l.limiters[key]
+	delete(kl.budgets, owner)
+	if len(kl.budgets) == 0 {
+		delete(l.limiters, key)
+		return
+	}
+	kl.apply()
+}
+
+// apply sets the bucket to the smallest rate and burst recorded for its key,
+// so the strictest owner's budget holds whichever owner connected last.
+func (kl *keyLimiter) apply() {
+	limit, burst := rate.Inf, 0
+	for _, rl := range kl.budgets {
+		l := rate.Inf
+		if rl.RequestsPerSecond > 0 {
+			l = rate.Limit(rl.RequestsPerSecond)
+		}
+		limit = min(limit, l)
+		if burst == 0 || rl.Burst < burst {
+			burst = rl.Burst
+		}
+	}
+	burst = max(burst, 1)
+	if kl.limiter.Limit() != limit {
+		kl.limiter.SetLimit(limit)
+	}
+	if kl.limiter.Burst() != burst {
+		kl.limiter.SetBurst(burst)
+	}
+}
+
+// WithRateLimit sets the request budget of the client's API key. Zero
+// fields fall back to the default budget.
+func WithRateLimit(rl RateLimit) Option {
+	return func(c *Client) {
+		c.rateLimit = rl
+	}
+}
+
+// WithRateLimitOwner sets the owner the client records its budget for its
+// API key as, usually the UID of its ProviderConfig.
+func WithRateLimitOwner(owner string) Option {
+	return func(c *Client) {
+		c.rateLimitOwner = owner
+	}
+}
+
+// WithLimiters makes the client take its token bucket from the supplied
+// registry instead of the process-wide one.
+func WithLimiters(l *Limiters) Option {
+	return func(c *Client) {
+		c.limiters = l
+	}
+}
+
+// rateLimitTransport is an http.RoundTripper that waits for a token before
+// every attempt of a request, including retries.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	rateLimiterWaitSeconds.Observe(time.Since(start).Seconds())
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,90 @@
+package komodor
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+)
+
+func TestLimitersGet(t *testing.T) {
+	type get struct {
+		key   string
+		owner string
+		rl    RateLimit
+	}
+	type want struct {
+		limit rate.Limit
+		burst int
+		same  bool
+		keys  []string
+	}
+
+	cases := map[string]struct {
+		reason string
+		gets   [2]get
+		want   want
+	}{
+		"SameKeyShared": {
+			reason: "Clients sharing an API key should share one token bucket.",
+			gets:   [2]get{{key: "a", owner: "x"}, {key: "a", owner: "y"}},
+			want:   want{limit: 5, burst: 10, same: true, keys: []string{"a"}},
+		},
+		"DifferentKeysIndependent": {
+			reason: "Clients with different API keys should get independent token buckets.",
+			gets:   [2]get{{key: "a", owner: "x"}, {key: "b", owner: "y"}},
+			want:   want{limit: 5, burst: 10, keys: []string{"a", "b"}},
+		},
+		"OverrideApplied": {
+			reason: "A configured budget should override the defaults of the key's bucket.",
+			gets:   [2]get{{key: "a", owner: "x"}, {key: "a", owner: "x", rl: RateLimit{RequestsPerSecond: 2, Burst: 3}}},
+			want:   want{limit: 2, burst: 3, same: true, keys: []string{"a"}},
+		},
+		"StrictestBudgetWins": {
+			reason: "Owners sharing an API key with different budgets should get the smallest rate and burst, whichever connected last.",
+			gets:   [2]get{{key: "a", owner: "x", rl: RateLimit{RequestsPerSecond: 2, Burst: 20}}, {key: "a", owner: "y", rl: RateLimit{RequestsPerSecond: 8, Burst: 4}}},
+			want:   want{limit: 2, burst: 4, same: true, keys: []string{"a"}},
+		},
+		"KeyRotated": {
+			reason: "An owner moving to another API key should release the bucket of its previous key.",
+			gets:   [2]get{{key: "a", owner: "x"}, {key: "b", owner: "x"}},
+			want:   want{limit: 5, burst: 10, keys: []string{"b"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := NewLimiters(RateLimit{RequestsPerSecond: 5, Burst: 10})
+			first := l.Get(tc.gets[0].key, tc.gets[0].owner, tc.gets[0].rl)
+			second := l.Get(tc.gets[1].key, tc.gets[1].owner, tc.gets[1].rl)
+
+			if got := first == second; got != tc.want.same {
+				t.Errorf("\n%s\nGet(...): want shared %t, got %t", tc.reason, tc.want.same, got)
+			}
+			if second.Limit() != tc.want.limit || second.Burst() != tc.want.burst {
+				t.Errorf("\n%s\nGet(...): want limit %v burst %d, got limit %v burst %d", tc.reason, tc.want.limit, tc.want.burst, second.Limit(), second.Burst())
+			}
+			keys := slices.Sorted(maps.Keys(l.limiters))
+			if diff := cmp.Diff(tc.want.keys, keys); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want keys, +got keys:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLimitersRelease(t *testing.T) {
+	l := NewLimiters(RateLimit{RequestsPerSecond: 5, Burst: 10})
+	strict := l.Get("a", "x", RateLimit{RequestsPerSecond: 1, Burst: 1})
+	l.Get("a", "y", RateLimit{})
+
+	l.Release("x")
+	if strict.Limit() != 5 || strict.Burst() != 10 {
+		t.Errorf("Release(...): want the remaining owner's budget, got limit %v burst %d", strict.Limit(), strict.Burst())
+	}
+	l.Release("y")
+	if len(l.limiters) != 0 || len(l.owners) != 0 {
+		t.Errorf("Release(...): want no limiters once every owner released, got %d limiters and %d owners", len(l.limiters), len(l.owners))
+	}
+}
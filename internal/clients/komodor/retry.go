@@ -0,0 +1,250 @@
+package komodor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// idempotencyKeyHeader marks a POST as safe to repeat. Every attempt of one
+// logical request carries the same key so the API can deduplicate them.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+var errNotRewindable = errors.New("cannot retry request: body cannot be rewound")
+
+// maxDrain bounds how much of a discarded response body is read so the
+// underlying connection can be reused.
+const maxDrain = 4 << 10
+
+// A RetryPolicy bounds how failed Komodor API requests are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is repeated after the
+	// first attempt. Zero disables retries.
+	MaxRetries int
+
+	// InitialBackoff is the base wait before the first retry. The wait
+	// doubles with every retry and is jittered.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the computed wait between two attempts. A Retry-After
+	// sent by Komodor is honored even when it is longer.
+	MaxBackoff time.Duration
+
+	// MaxElapsed caps the total time spent on all attempts and waits of one
+	// request. Zero means no cap beyond MaxRetries.
+	MaxElapsed time.Duration
+}
+
+// DefaultRetryPolicy is used when a ProviderConfig does not configure
+// retries.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	MaxElapsed:     30 * time.Second,
+}
+
+// WithRetryPolicy sets how failed requests are retried.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = p
+	}
+}
+
+// retryTransport is an http.RoundTripper that repeats requests failing with
+// a transient error. Every attempt gets its own timeout so a slow attempt
+// does not consume the budget of the ones after it.
+type retryTransport struct {
+	next           http.RoundTripper
+	policy         RetryPolicy
+	attemptTimeout time.Duration
+
+	// sleep waits for d or until ctx is done. It is replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, p RetryPolicy, attemptTimeout time.Duration) *retryTransport {
+	return &retryTransport{next: next, policy: p, attemptTimeout: attemptTimeout, sleep: sleepContext}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+	retryable := isIdempotent(req)
+
+	for attempt := 0; ; attempt++ {
+		r := req
+		if attempt > 0 {
+			var err error
+			if r, err = rewind(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.attempt(r)
+
+		if attempt >= t.policy.MaxRetries || ctx.Err() != nil {
+			return resp, err
+		}
+		reason, ok := shouldRetry(resp, err, retryable)
+		if !ok {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if t.policy.MaxElapsed > 0 && time.Since(start)+wait > t.policy.MaxElapsed {
+			return resp, err
+		}
+		if resp != nil {
+			discard(resp)
+		}
+
+		retriesTotal.WithLabelValues(req.Method, reason).Inc()
+		trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+			attribute.String("reason", reason),
+			attribute.Int("attempt", attempt+1),
+			attribute.String("wait", wait.String()),
+		))
+		if err := t.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt performs a single request bounded by the per-attempt timeout. The
+// timeout stays armed until the response body is closed.
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	if t.attemptTimeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.attemptTimeout)
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff returns how long to wait before the retry following attempt. A
+// Retry-After header takes precedence over the computed backoff.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	d := t.policy.InitialBackoff << attempt
+	if d <= 0 || (t.policy.MaxBackoff > 0 && d > t.policy.MaxBackoff) {
+		d = t.policy.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Equal jitter keeps at least half the backoff while spreading out
+	// clients that failed at the same time.
+	half := d / 2
+	return half + rand.N(half+1) //nolint:gosec // Jitter does not need a secure source.
+}
+
+// shouldRetry reports whether a request that produced resp or err may be
+// repeated, and the reason recorded in metrics.
+func shouldRetry(resp *http.Response, err error, idempotent bool) (string, bool) {
+	if err != nil {
+		return "error", idempotent
+	}
+	if !isRetryableStatus(resp.StatusCode) {
+		return "", false
+	}
+	// A rate limited request was never processed, so it is safe to repeat
+	// whatever its method.
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return strconv.Itoa(resp.StatusCode), true
+	}
+	return strconv.Itoa(resp.StatusCode), idempotent
+}
+
+// isIdempotent returns true if repeating req cannot create duplicate
+// resources. A POST is only repeated when it carries an idempotency key.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return req.Header.Get(idempotencyKeyHeader) != ""
+	}
+	return false
+}
+
+// rewind returns a copy of req with a fresh body.
+func rewind(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return r, nil
+	}
+	if req.GetBody == nil {
+		return nil, errNotRewindable
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = body
+	return r, nil
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// discard drains and closes a response that will not be returned.
+func discard(resp *http.Response) {
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrain)
+	_ = resp.Body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// cancelOnClose releases a per-attempt context once the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
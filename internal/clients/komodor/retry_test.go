@@ -0,0 +1,133 @@
+package komodor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// roundTripFn adapts a function to an http.RoundTripper.
+type roundTripFn func(*http.Request) (*http.Response, error)
+
+func (f roundTripFn) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func response(code int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: code, Header: header, Body: io.NopCloser(strings.NewReader(""))}
+}
+
+func TestRetryTransport(t *testing.T) {
+	type want struct {
+		status   int
+		attempts int
+		waits    []time.Duration
+	}
+
+	cases := map[string]struct {
+		reason    string
+		method    string
+		header    http.Header
+		responses []*http.Response
+		policy    RetryPolicy
+		want      want
+	}{
+		"RetryAfterHonored": {
+			reason: "A GET rate limited with Retry-After should wait the requested time and succeed.",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				response(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"7"}}),
+				response(http.StatusOK, nil),
+			},
+			policy: RetryPolicy{MaxRetries: 3, InitialBackoff: time.Second, MaxBackoff: time.Second},
+			want:   want{status: http.StatusOK, attempts: 2, waits: []time.Duration{7 * time.Second}},
+		},
+		"RetriesExhausted": {
+			reason: "A GET that keeps failing should return the last response once retries are exhausted.",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				response(http.StatusBadGateway, http.Header{"Retry-After": []string{"0"}}),
+				response(http.StatusBadGateway, http.Header{"Retry-After": []string{"0"}}),
+				response(http.StatusBadGateway, http.Header{"Retry-After": []string{"0"}}),
+			},
+			policy: RetryPolicy{MaxRetries: 2},
+			want:   want{status: http.StatusBadGateway, attempts: 3, waits: []time.Duration{0, 0}},
+		},
+		"BudgetExceeded": {
+			reason: "A wait longer than the elapsed budget should not be taken.",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				response(http.StatusServiceUnavailable, http.Header{"Retry-After": []string{"60"}}),
+			},
+			policy: RetryPolicy{MaxRetries: 3, MaxElapsed: 30 * time.Second},
+			want:   want{status: http.StatusServiceUnavailable, attempts: 1},
+		},
+		"PostWithoutIdempotencyKey": {
+			reason: "A POST without an idempotency key should not be repeated after a 502.",
+			method: http.MethodPost,
+			responses: []*http.Response{
+				response(http.StatusBadGateway, nil),
+			},
+			policy: RetryPolicy{MaxRetries: 3},
+			want:   want{status: http.StatusBadGateway, attempts: 1},
+		},
+		"PostWithIdempotencyKey": {
+			reason: "A POST with an idempotency key should be repeated after a 502.",
+			method: http.MethodPost,
+			header: http.Header{idempotencyKeyHeader: []string{"k"}},
+			responses: []*http.Response{
+				response(http.StatusBadGateway, http.Header{"Retry-After": []string{"1"}}),
+				response(http.StatusCreated, nil),
+			},
+			policy: RetryPolicy{MaxRetries: 3},
+			want:   want{status: http.StatusCreated, attempts: 2, waits: []time.Duration{time.Second}},
+		},
+		"ClientErrorNotRetried": {
+			reason: "A 400 should never be retried.",
+			method: http.MethodGet,
+			responses: []*http.Response{
+				response(http.StatusBadRequest, nil),
+			},
+			policy: RetryPolicy{MaxRetries: 3},
+			want:   want{status: http.StatusBadRequest, attempts: 1},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			var waits []time.Duration
+			rt := newRetryTransport(roundTripFn(func(_ *http.Request) (*http.Response, error) {
+				r := tc.responses[attempts]
+				attempts++
+				return r, nil
+			}), tc.policy, 0)
+			rt.sleep = func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			req, _ := http.NewRequestWithContext(context.Background(), tc.method, "https://komodor.test/", strings.NewReader("{}"))
+			for k, v := range tc.header {
+				req.Header[k] = v
+			}
+
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("\n%s\nRoundTrip(...): unexpected error: %v", tc.reason, err)
+			}
+			_ = resp.Body.Close()
+
+			got := want{status: resp.StatusCode, attempts: attempts, waits: waits}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nRoundTrip(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
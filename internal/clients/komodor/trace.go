@@ -0,0 +1,44 @@
+package komodor
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/crossplane/provider-komodor/internal/tracing"
+)
+
+var tracer = otel.Tracer("github.com/crossplane/provider-komodor/internal/clients/komodor")
+
+// traceTransport is an http.RoundTripper that records a client span for
+// every request, as a child of the span of the request's context. It sits
+// above the retrying transport, so the retries of a request are events of
+// its span.
+type traceTransport struct {
+	next           http.RoundTripper
+	providerConfig string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer.Start(req.Context(), "Komodor "+requestOperation(req),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.String()),
+			attribute.String("komodor.provider_config", t.providerConfig),
+		))
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err == nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	tracing.End(span, err)
+	return resp, err
+}
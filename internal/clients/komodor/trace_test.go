@@ -0,0 +1,71 @@
+package komodor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTraceTransport(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	base, _ := url.Parse(srv.URL)
+	c := NewClient("key", WithBaseURL(base), WithProviderConfig("trace-test"), WithRetryPolicy(RetryPolicy{MaxRetries: 1}))
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "Observe")
+	resp, err := c.doRequest(ctx, http.MethodGet, monitorsPath+"/m-1", nil, nil)
+	if err != nil {
+		t.Fatalf("doRequest(...): unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	parent.End()
+
+	type span struct {
+		Name   string
+		Parent bool
+		Events []string
+		Status int64
+	}
+	var got []span
+	for _, s := range rec.Ended() {
+		if s.Name() == "Observe" {
+			continue
+		}
+		sp := span{Name: s.Name(), Parent: s.Parent().SpanID() == parent.SpanContext().SpanID()}
+		for _, e := range s.Events() {
+			sp.Events = append(sp.Events, e.Name)
+		}
+		for _, a := range s.Attributes() {
+			if a.Key == attribute.Key("http.response.status_code") {
+				sp.Status = a.Value.AsInt64()
+			}
+		}
+		got = append(got, sp)
+	}
+
+	want := []span{{Name: "Komodor GetMonitor", Parent: true, Events: []string{"retry"}, Status: http.StatusOK}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("doRequest(...): -want, +got:\n%s", diff)
+	}
+}
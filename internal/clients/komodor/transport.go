@@ -0,0 +1,87 @@
+package komodor
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WithProxy sends requests through the HTTP(S) proxy at the supplied URL
+// instead of the proxy selected by the HTTPS_PROXY and NO_PROXY environment
+// variables.
+func WithProxy(u *url.URL) Option {
+	return func(c *Client) {
+		c.proxyURL = u
+	}
+}
+
+// WithCABundle trusts the PEM encoded CA certificates of the supplied bundle
+// in addition to the system's.
+func WithCABundle(pem []byte) Option {
+	return func(c *Client) {
+		c.caBundle = pem
+	}
+}
+
+// WithMinTLSVersion sets the lowest TLS version the client accepts, e.g.
+// tls.VersionTLS13.
+func WithMinTLSVersion(v uint16) Option {
+	return func(c *Client) {
+		c.minTLSVersion = v
+	}
+}
+
+// WithTimeout bounds a single attempt of a request.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// ValidateCABundle returns an error if the supplied bundle holds no PEM
+// encoded certificate.
+func ValidateCABundle(pem []byte) error {
+	if !x509.NewCertPool().AppendCertsFromPEM(pem) {
+		return errors.New("CA bundle contains no PEM encoded certificate")
+	}
+	return nil
+}
+
+// CloseIdleConnections closes the idle connections of a client with a
+// transport of its own. It does not interrupt requests in flight. Clients
+// that share http.DefaultTransport leave its connections to the others.
+func (c *Client) CloseIdleConnections() {
+	if c.ownTransport != nil {
+		c.ownTransport.CloseIdleConnections()
+	}
+}
+
+// transport returns the transport requests are sent with. Clients that keep
+// the defaults share http.DefaultTransport and its connection pool.
+func (c *Client) transport() http.RoundTripper {
+	if c.proxyURL == nil && len(c.caBundle) == 0 && c.minTLSVersion == 0 {
+		return http.DefaultTransport
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if c.proxyURL != nil {
+		t.Proxy = http.ProxyURL(c.proxyURL)
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.minTLSVersion != 0 {
+		cfg.MinVersion = c.minTLSVersion
+	}
+	if len(c.caBundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pool.AppendCertsFromPEM(c.caBundle)
+		cfg.RootCAs = pool
+	}
+	t.TLSClientConfig = cfg
+	return t
+}
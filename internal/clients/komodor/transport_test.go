@@ -0,0 +1,85 @@
+package komodor
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestTransportTLS(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	base, _ := url.Parse(srv.URL)
+
+	cases := map[string]struct {
+		reason  string
+		opts    []Option
+		wantErr bool
+	}{
+		"UntrustedCA": {
+			reason:  "A server whose CA is not trusted should be rejected.",
+			wantErr: true,
+		},
+		"CABundle": {
+			reason: "A server whose CA is in the CA bundle should be trusted.",
+			opts:   []Option{WithCABundle(ca)},
+		},
+		"MinTLSVersion": {
+			reason:  "A server that does not support the minimum TLS version should be rejected.",
+			opts:    []Option{WithCABundle(ca), WithMinTLSVersion(tls.VersionTLS13)},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewClient("key", append(tc.opts, WithBaseURL(base), WithRetryPolicy(RetryPolicy{}))...)
+			resp, err := c.doRequest(context.Background(), http.MethodGet, "/", nil, nil)
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("\n%s\ndoRequest(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	p, _ := url.Parse(proxy.URL)
+	base, _ := url.Parse("http://komodor.invalid")
+	c := NewClient("key", WithBaseURL(base), WithProxy(p), WithRetryPolicy(RetryPolicy{}))
+
+	resp, err := c.doRequest(context.Background(), http.MethodGet, clustersPath, nil, nil)
+	if err != nil {
+		t.Fatalf("doRequest(...): unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if want := "http://komodor.invalid" + clustersPath; proxied != want {
+		t.Errorf("doRequest(...): want request for %q sent through the proxy, got %q", want, proxied)
+	}
+}
+
+func TestValidateCABundle(t *testing.T) {
+	if err := ValidateCABundle([]byte("not a certificate")); err == nil {
+		t.Errorf("ValidateCABundle(...): want error for a bundle without certificates")
+	}
+}
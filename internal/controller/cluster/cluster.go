@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	apisv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+const (
+	errNotCluster   = "managed resource is not a Cluster custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errNewClient    = "cannot create new Service"
+	errListClusters = "cannot list clusters in Komodor"
+	errObserveOnly  = "Komodor clusters are onboarded by installing the Komodor agent and can only be observed, set spec.managementPolicies to [\"Observe\"]"
+)
+
+// KomodorClient is the subset of the Komodor client a Cluster needs.
+type KomodorClient interface {
+	CachedClusters(ctx context.Context) ([]komodorclient.Cluster, error)
+}
+
+// Setup adds a controller that reconciles Cluster managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1beta1.ClusterGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			inventory: komodorclient.NewClusterInventory(),
+			clients:   komodorclient.NewClientCache()}),
+		// The external name is the Komodor cluster ID, which is only known
+		// once the cluster has been observed.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithManagementPolicies(),
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1beta1.ClusterList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind v1beta1.ClusterList")
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1beta1.ClusterGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1beta1.Cluster{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+
+	// inventory caches the Komodor cluster inventory across all clients
+	// this connector creates, so observing many Clusters costs a single
+	// list of the inventory per TTL.
+	inventory *komodorclient.ClusterInventory
+
+	// clients reuses Komodor clients across reconciles.
+	clients *komodorclient.ClientCache
+}
+
+// Connect produces an ExternalClient authenticated with the credentials of
+// the Cluster's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.Cluster)
+	if !ok {
+		return nil, errors.New(errNotCluster)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	client, err := komodorclient.ProviderConfigClient(ctx, c.kube, pc, c.clients, komodorclient.WithClusterInventory(c.inventory))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{client: client}, nil
+}
+
+// external observes Komodor clusters using the Komodor client.
+type external struct {
+	client KomodorClient
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.Cluster)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCluster)
+	}
+
+	clusters, err := c.client.CachedClusters(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListClusters)
+	}
+
+	var cluster *komodorclient.Cluster
+	for i := range clusters {
+		if clusters[i].Name == cr.Spec.ForProvider.Name {
+			cluster = &clusters[i]
+			break
+		}
+	}
+	if cluster == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider = v1beta1.ClusterObservation{
+		ID:           cluster.ID,
+		Name:         cluster.Name,
+		APIServerURL: cluster.APIServerURL,
+		Tags:         cluster.Tags,
+		CreatedAt:    cluster.CreatedAt,
+		UpdatedAt:    cluster.UpdatedAt,
+	}
+	cr.SetConditions(xpv1.Available())
+
+	// Record the cluster ID as the external name the first time the cluster
+	// is seen, or when it was onboarded again under a new ID.
+	lateInitialized := cluster.ID != "" && meta.GetExternalName(cr) != cluster.ID
+	if lateInitialized {
+		meta.SetExternalName(cr, cluster.ID)
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
+	}, nil
+}
+
+func (c *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, errors.New(errObserveOnly)
+}
+
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, errors.New(errObserveOnly)
+}
+
+// Delete leaves the Komodor cluster in place, it is removed by uninstalling
+// the Komodor agent.
+func (c *external) Delete(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil
+}
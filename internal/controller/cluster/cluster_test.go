@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+type mockClient struct {
+	clusters []komodorclient.Cluster
+	err      error
+}
+
+func (m *mockClient) CachedClusters(_ context.Context) ([]komodorclient.Cluster, error) {
+	return m.clusters, m.err
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	prod := komodorclient.Cluster{
+		ID:           "c-1",
+		Name:         "prod",
+		APIServerURL: "https://prod.example.com",
+		Tags:         map[string]string{"env": "prod"},
+		CreatedAt:    "2025-01-01T00:00:00Z",
+	}
+
+	type want struct {
+		o            managed.ExternalObservation
+		atProvider   v1beta1.ClusterObservation
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason       string
+		client       *mockClient
+		externalName string
+		want         want
+	}{
+		"Onboarded": {
+			reason: "A cluster known to Komodor should exist, be mirrored into status and record its ID as external name.",
+			client: &mockClient{clusters: []komodorclient.Cluster{{ID: "c-2", Name: "staging"}, prod}},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				atProvider: v1beta1.ClusterObservation{
+					ID:           "c-1",
+					Name:         "prod",
+					APIServerURL: "https://prod.example.com",
+					Tags:         map[string]string{"env": "prod"},
+					CreatedAt:    "2025-01-01T00:00:00Z",
+				},
+				externalName: "c-1",
+			},
+		},
+		"AlreadyRecorded": {
+			reason:       "A cluster whose ID is already recorded should not be late initialized again.",
+			client:       &mockClient{clusters: []komodorclient.Cluster{prod}},
+			externalName: "c-1",
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				atProvider: v1beta1.ClusterObservation{
+					ID:           "c-1",
+					Name:         "prod",
+					APIServerURL: "https://prod.example.com",
+					Tags:         map[string]string{"env": "prod"},
+					CreatedAt:    "2025-01-01T00:00:00Z",
+				},
+				externalName: "c-1",
+			},
+		},
+		"NotOnboarded": {
+			reason: "A cluster unknown to Komodor should not exist.",
+			client: &mockClient{clusters: []komodorclient.Cluster{{ID: "c-2", Name: "staging"}}},
+			want:   want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ListError": {
+			reason: "Errors listing clusters should be returned.",
+			client: &mockClient{err: errBoom},
+			want:   want{err: errors.Wrap(errBoom, errListClusters)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1beta1.Cluster{Spec: v1beta1.ClusterSpec{ForProvider: v1beta1.ClusterParameters{Name: "prod"}}}
+			if tc.externalName != "" {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+			e := external{client: tc.client}
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.atProvider, cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -17,18 +17,60 @@ limitations under the License.
 package config
 
 import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/providerconfig"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
-	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/provider-komodor/apis/v1alpha1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+const (
+	errGetPC        = "cannot get ProviderConfig"
+	errGetCreds     = "cannot get credentials"
+	errNoCreds      = "credentials are empty"
+	errGetCABundle  = "cannot get CA bundle"
+	errNewClient    = "cannot create new Service"
+	errListClusters = "cannot list clusters in Komodor"
+	errUpdateStatus = "cannot update ProviderConfig status"
+
+	reasonCredentialsValid   event.Reason = "CredentialsValid"
+	reasonCredentialsInvalid event.Reason = "CredentialsInvalid"
+)
+
+// KomodorClient is the subset of the Komodor client a credentials check
+// needs.
+type KomodorClient interface {
+	ListClusters(ctx context.Context) ([]komodorclient.Cluster, error)
+	CloseIdleConnections()
+}
+
+var (
+	newKomodorClient = func(pc *v1alpha1.ProviderConfig, keys komodorclient.APIKeys, caBundle []byte) (KomodorClient, error) {
+		return komodorclient.NewProviderConfigClient(pc, keys, caBundle)
+	}
 )
 
 // Setup adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
+// their current usage and checking that Komodor accepts their credentials.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := providerconfig.ControllerName(v1alpha1.ProviderConfigGroupKind)
 
@@ -37,14 +79,239 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		UsageList: v1alpha1.ProviderConfigUsageListGroupVersionKind,
 	}
 
-	r := providerconfig.NewReconciler(mgr, of,
-		providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
-		providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	r := &healthReconciler{
+		usage: providerconfig.NewReconciler(mgr, of,
+			providerconfig.WithLogger(o.Logger.WithValues("controller", name)),
+			providerconfig.WithRecorder(recorder)),
+		kube:         mgr.GetClient(),
+		log:          o.Logger.WithValues("controller", name),
+		record:       recorder,
+		interval:     o.PollInterval,
+		minInterval:  minCheckInterval,
+		evict:        komodorclient.EvictAll,
+		newServiceFn: newKomodorClient,
+		checked:      map[string]check{},
+	}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&v1alpha1.ProviderConfig{}).
 		Watches(&v1alpha1.ProviderConfigUsage{}, &resource.EnqueueRequestForProviderConfig{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.providerConfigsForSecret)).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
+
+// minCheckInterval is the least time between two checks of the credentials
+// of a ProviderConfig. Each check lists every cluster of the account, so
+// bursts of spec or Secret changes must not each trigger one.
+const minCheckInterval = 30 * time.Second
+
+// A check records when the credentials of a generation of a ProviderConfig
+// were last checked. A stale check was made before the credentials changed.
+type check struct {
+	uid        types.UID
+	generation int64
+	at         time.Time
+	stale      bool
+}
+
+// A healthReconciler checks that Komodor accepts the credentials of a
+// ProviderConfig once the usage reconciler has accounted for its users. The
+// credentials are checked again every interval, or once minInterval has
+// passed since the last check when the ProviderConfig or its credentials
+// change. The cached clients of a deleted ProviderConfig are evicted.
+type healthReconciler struct {
+	usage        reconcile.Reconciler
+	kube         client.Client
+	log          logging.Logger
+	record       event.Recorder
+	interval     time.Duration
+	minInterval  time.Duration
+	evict        func(uid types.UID)
+	newServiceFn func(pc *v1alpha1.ProviderConfig, keys komodorclient.APIKeys, caBundle []byte) (KomodorClient, error)
+
+	mu      sync.Mutex
+	checked map[string]check
+}
+
+func (r *healthReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.usage.Reconcile(ctx, req)
+	if err != nil {
+		return result, err
+	}
+
+	pc := &v1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			if uid, ok := r.forget(req.Name); ok {
+				r.evict(uid)
+			}
+		}
+		return result, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+	if meta.WasDeleted(pc) {
+		r.forget(pc.GetName())
+		r.evict(pc.GetUID())
+		return result, nil
+	}
+
+	if wait := r.untilDue(pc); wait > 0 {
+		return requeueAfter(result, wait), nil
+	}
+
+	r.checkCredentials(ctx, pc)
+	if err := r.kube.Status().Update(ctx, pc); err != nil {
+		if kerrors.IsConflict(err) {
+			return reconcile.Result{Requeue: true}, nil
+		}
+		return result, errors.Wrap(err, errUpdateStatus)
+	}
+	r.remember(pc)
+	return requeueAfter(result, r.interval), nil
+}
+
+// checkCredentials lists the clusters of the Komodor account with the
+// ProviderConfig's credentials, and records the result in its conditions
+// and cluster count.
+func (r *healthReconciler) checkCredentials(ctx context.Context, pc *v1alpha1.ProviderConfig) {
+	previous := pc.Status.GetCondition(v1alpha1.TypeCredentialsValid)
+
+	valid := v1alpha1.CredentialsValid()
+	n, err := r.countClusters(ctx, pc)
+	switch {
+	case err == nil:
+		pc.Status.ClusterCount = &n
+	case isInvalidCredentials(err):
+		valid = v1alpha1.CredentialsInvalid(err)
+		pc.Status.ClusterCount = nil
+	default:
+		// Komodor could not be asked, the credentials may still be valid.
+		valid = v1alpha1.CredentialsUnknown(err)
+	}
+	ready := xpv1.Available()
+	if err != nil {
+		ready = xpv1.Unavailable().WithMessage(err.Error())
+	}
+	pc.SetConditions(ready, valid)
+	r.log.Debug("Checked ProviderConfig credentials", "name", pc.GetName(), "valid", valid.Status, "message", valid.Message)
+
+	if valid.Status == previous.Status {
+		return
+	}
+	switch valid.Reason {
+	case v1alpha1.ReasonValidCredentials:
+		r.record.Event(pc, event.Normal(reasonCredentialsValid, "Komodor accepted the credentials"))
+	case v1alpha1.ReasonInvalidCredentials:
+		r.record.Event(pc, event.Warning(reasonCredentialsInvalid, err))
+	}
+}
+
+// countClusters counts the clusters of the Komodor account with the
+// ProviderConfig's credentials. It lists every page of clusters, which is
+// why checks are spaced by at least minInterval.
+func (r *healthReconciler) countClusters(ctx context.Context, pc *v1alpha1.ProviderConfig) (int, error) {
+	keys, err := komodorclient.ProviderConfigAPIKeys(ctx, r.kube, pc)
+	if err != nil {
+		return 0, credentialsError{errors.Wrap(err, errGetCreds)}
+	}
+	if len(keys.Primary) == 0 {
+		return 0, credentialsError{errors.New(errNoCreds)}
+	}
+	caBundle, err := komodorclient.ProviderConfigCABundle(ctx, r.kube, pc)
+	if err != nil {
+		return 0, errors.Wrap(err, errGetCABundle)
+	}
+	svc, err := r.newServiceFn(pc, keys, caBundle)
+	if err != nil {
+		return 0, errors.Wrap(err, errNewClient)
+	}
+	// Checks build a client of their own, which must not keep connections
+	// open until the next check.
+	defer svc.CloseIdleConnections()
+	clusters, err := svc.ListClusters(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, errListClusters)
+	}
+	return len(clusters), nil
+}
+
+// A credentialsError is an error reading the credentials of a
+// ProviderConfig.
+type credentialsError struct {
+	error
+}
+
+// isInvalidCredentials returns true if the supplied error shows that the
+// credentials are missing or that Komodor rejected them.
+func isInvalidCredentials(err error) bool {
+	var ce credentialsError
+	return errors.As(err, &ce) || komodorclient.IsUnauthorized(err) || komodorclient.IsForbidden(err)
+}
+
+// providerConfigsForSecret maps a Secret to the ProviderConfigs that read
+// their credentials from it, and marks their last check stale so rotated
+// credentials are checked as soon as minInterval allows.
+func (r *healthReconciler) providerConfigsForSecret(ctx context.Context, o client.Object) []reconcile.Request {
+	pcs, err := komodorclient.ProviderConfigsReferencingSecret(ctx, r.kube, o)
+	if err != nil {
+		r.log.Info("Cannot list ProviderConfigs reading credentials from Secret", "secret", o.GetNamespace()+"/"+o.GetName(), "error", err)
+		return nil
+	}
+	reqs := make([]reconcile.Request, 0, len(pcs))
+	for _, pc := range pcs {
+		r.invalidate(pc.GetName())
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: pc.GetName()}})
+	}
+	return reqs
+}
+
+// untilDue returns how long to wait before the credentials of the supplied
+// ProviderConfig are due to be checked again.
+func (r *healthReconciler) untilDue(pc *v1alpha1.ProviderConfig) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.checked[pc.GetName()]
+	if !ok || c.uid != pc.GetUID() {
+		return 0
+	}
+	if c.stale || c.generation != pc.GetGeneration() {
+		return time.Until(c.at.Add(r.minInterval))
+	}
+	return time.Until(c.at.Add(r.interval))
+}
+
+func (r *healthReconciler) remember(pc *v1alpha1.ProviderConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checked[pc.GetName()] = check{uid: pc.GetUID(), generation: pc.GetGeneration(), at: time.Now()}
+}
+
+func (r *healthReconciler) invalidate(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.checked[name]; ok {
+		c.stale = true
+		r.checked[name] = c
+	}
+}
+
+// forget drops the last check of the named ProviderConfig, returning the
+// UID it was made for.
+func (r *healthReconciler) forget(name string) (types.UID, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.checked[name]
+	delete(r.checked, name)
+	return c.uid, ok
+}
+
+// requeueAfter returns the supplied result, requeued no later than after the
+// supplied duration.
+func requeueAfter(result reconcile.Result, d time.Duration) reconcile.Result {
+	if d > 0 && (result.RequeueAfter == 0 || d < result.RequeueAfter) {
+		result.RequeueAfter = d
+	}
+	return result
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-komodor/apis/v1alpha1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+type mockClient struct {
+	clusters []komodorclient.Cluster
+	err      error
+	calls    int
+	closed   int
+}
+
+func (m *mockClient) ListClusters(context.Context) ([]komodorclient.Cluster, error) {
+	m.calls++
+	return m.clusters, m.err
+}
+
+func (m *mockClient) CloseIdleConnections() {
+	m.closed++
+}
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	interval := time.Minute
+
+	pc := func() *v1alpha1.ProviderConfig {
+		return &v1alpha1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", UID: "pc-uid", Generation: 1},
+			Spec: v1alpha1.ProviderConfigSpec{Credentials: v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "komodor"},
+						Key:             "apiKey",
+					},
+				},
+			}},
+		}
+	}
+	get := func(secretErr error) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1alpha1.ProviderConfig:
+				pc().DeepCopyInto(o)
+			case *corev1.Secret:
+				if secretErr != nil {
+					return secretErr
+				}
+				o.Data = map[string][]byte{"apiKey": []byte("s3cr3t")}
+			}
+			return nil
+		}
+	}
+	unauthorized := &komodorclient.APIError{StatusCode: 401, Status: "401 Unauthorized"}
+
+	type want struct {
+		result     reconcile.Result
+		conditions []xpv1.Condition
+		clusters   *int
+		calls      int
+		evicted    []types.UID
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason      string
+		get         test.MockGetFn
+		client      *mockClient
+		minInterval time.Duration
+		checked     map[string]check
+		want        want
+	}{
+		"CredentialsValid": {
+			reason: "Credentials Komodor accepts should make the ProviderConfig ready and record the cluster count.",
+			get:    get(nil),
+			client: &mockClient{clusters: []komodorclient.Cluster{{Name: "prod"}, {Name: "staging"}}},
+			want: want{
+				result:     reconcile.Result{RequeueAfter: interval},
+				conditions: []xpv1.Condition{xpv1.Available(), v1alpha1.CredentialsValid()},
+				clusters:   ptr.To(2),
+				calls:      1,
+			},
+		},
+		"CredentialsRejected": {
+			reason: "Credentials Komodor rejects should be reported as invalid with Komodor's message.",
+			get:    get(nil),
+			client: &mockClient{err: unauthorized},
+			want: want{
+				result: reconcile.Result{RequeueAfter: interval},
+				conditions: []xpv1.Condition{
+					xpv1.Unavailable().WithMessage(errors.Wrap(unauthorized, errListClusters).Error()),
+					v1alpha1.CredentialsInvalid(errors.Wrap(unauthorized, errListClusters)),
+				},
+				calls: 1,
+			},
+		},
+		"CredentialsMissing": {
+			reason: "Credentials that cannot be read should be reported as invalid without calling Komodor.",
+			get:    get(errBoom),
+			client: &mockClient{},
+			want: want{
+				result: reconcile.Result{RequeueAfter: interval},
+				conditions: []xpv1.Condition{
+					xpv1.Unavailable().WithMessage("cannot get credentials: cannot get credentials secret: boom"),
+					v1alpha1.CredentialsInvalid(errors.New("cannot get credentials: cannot get credentials secret: boom")),
+				},
+			},
+		},
+		"KomodorUnreachable": {
+			reason: "A failure unrelated to the credentials should leave their validity unknown.",
+			get:    get(nil),
+			client: &mockClient{err: errBoom},
+			want: want{
+				result: reconcile.Result{RequeueAfter: interval},
+				conditions: []xpv1.Condition{
+					xpv1.Unavailable().WithMessage(errors.Wrap(errBoom, errListClusters).Error()),
+					v1alpha1.CredentialsUnknown(errors.Wrap(errBoom, errListClusters)),
+				},
+				calls: 1,
+			},
+		},
+		"NotDue": {
+			reason:  "Credentials checked recently for the same generation should not be checked again.",
+			get:     get(nil),
+			client:  &mockClient{},
+			checked: map[string]check{"default": {uid: "pc-uid", generation: 1, at: time.Now()}},
+			want: want{
+				result: reconcile.Result{RequeueAfter: interval},
+			},
+		},
+		"CredentialsRotated": {
+			reason:  "Credentials marked stale since the last check should be checked again.",
+			get:     get(nil),
+			client:  &mockClient{},
+			checked: map[string]check{"default": {uid: "pc-uid", generation: 1, at: time.Now(), stale: true}},
+			want: want{
+				result:     reconcile.Result{RequeueAfter: interval},
+				conditions: []xpv1.Condition{xpv1.Available(), v1alpha1.CredentialsValid()},
+				clusters:   ptr.To(0),
+				calls:      1,
+			},
+		},
+		"SpecChangedTooSoon": {
+			reason:      "A ProviderConfig that changed within the minimum interval of the last check should be checked once it has passed.",
+			get:         get(nil),
+			client:      &mockClient{},
+			minInterval: interval,
+			checked:     map[string]check{"default": {uid: "pc-uid", generation: 0, at: time.Now()}},
+			want: want{
+				result: reconcile.Result{RequeueAfter: interval},
+			},
+		},
+		"Deleted": {
+			reason: "The cached clients of a deleted ProviderConfig should be evicted without calling Komodor.",
+			get: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				pc().DeepCopyInto(obj.(*v1alpha1.ProviderConfig))
+				obj.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+				return nil
+			},
+			client:  &mockClient{},
+			checked: map[string]check{"default": {uid: "pc-uid", generation: 1, at: time.Now()}},
+			want: want{
+				evicted: []types.UID{"pc-uid"},
+			},
+		},
+		"Gone": {
+			reason:  "The cached clients of a ProviderConfig that no longer exists should be evicted.",
+			get:     test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "default")),
+			client:  &mockClient{},
+			checked: map[string]check{"default": {uid: "pc-uid", generation: 1, at: time.Now()}},
+			want: want{
+				evicted: []types.UID{"pc-uid"},
+			},
+		},
+		"SpecChanged": {
+			reason:  "Credentials of a ProviderConfig that changed since the last check should be checked again.",
+			get:     get(nil),
+			client:  &mockClient{},
+			checked: map[string]check{"default": {uid: "pc-uid", generation: 0, at: time.Now()}},
+			want: want{
+				result:     reconcile.Result{RequeueAfter: interval},
+				conditions: []xpv1.Condition{xpv1.Available(), v1alpha1.CredentialsValid()},
+				clusters:   ptr.To(0),
+				calls:      1,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			checked := tc.checked
+			if checked == nil {
+				checked = map[string]check{}
+			}
+			r := &healthReconciler{
+				usage: reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{}, nil
+				}),
+				kube: &test.MockClient{
+					MockGet: tc.get,
+					MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+						pc := obj.(*v1alpha1.ProviderConfig)
+						got.conditions = pc.Status.Conditions
+						got.clusters = pc.Status.ClusterCount
+						return nil
+					},
+				},
+				log:         logging.NewNopLogger(),
+				record:      event.NewNopRecorder(),
+				interval:    interval,
+				minInterval: tc.minInterval,
+				evict: func(uid types.UID) {
+					got.evicted = append(got.evicted, uid)
+				},
+				newServiceFn: func(*v1alpha1.ProviderConfig, komodorclient.APIKeys, []byte) (KomodorClient, error) {
+					return tc.client, nil
+				},
+				checked: checked,
+			}
+
+			got.result, got.err = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			got.calls = tc.client.calls
+			if tc.client.closed != tc.client.calls {
+				t.Errorf("\n%s\nr.Reconcile(...): want the client closed after each of %d checks, closed %d times", tc.reason, tc.client.calls, tc.client.closed)
+			}
+			if got.result.RequeueAfter > 0 {
+				// The wait of a check that is not due depends on the clock.
+				got.result.RequeueAfter = got.result.RequeueAfter.Round(interval)
+			}
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors(), test.EquateConditions(), cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
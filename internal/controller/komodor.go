@@ -20,6 +20,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/crossplane/provider-komodor/internal/controller/cluster"
+	"github.com/crossplane/provider-komodor/internal/controller/config"
+	"github.com/crossplane/provider-komodor/internal/controller/monitorset"
+	"github.com/crossplane/provider-komodor/internal/controller/notificationchannel"
 	"github.com/crossplane/provider-komodor/internal/controller/realtimemonitor"
 )
 
@@ -27,6 +31,10 @@ import (
 // the supplied manager.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	for _, setup := range []func(ctrl.Manager, controller.Options) error{
+		config.Setup,
+		cluster.Setup,
+		monitorset.Setup,
+		notificationchannel.Setup,
 		realtimemonitor.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
@@ -35,3 +43,16 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	}
 	return nil
 }
+
+// SetupWebhooks adds the conversion webhooks of all Komodor resources with
+// more than one API version to the supplied manager.
+func SetupWebhooks(mgr ctrl.Manager) error {
+	for _, setup := range []func(ctrl.Manager) error{
+		realtimemonitor.SetupWebhook,
+	} {
+		if err := setup(mgr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
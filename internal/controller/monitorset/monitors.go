@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitorset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+// invalidNameChars are the characters of a Komodor cluster name that may not
+// appear in a Kubernetes object name.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Helper: Select the names of the Komodor clusters a generator generates
+func generateClusters(g *v1beta1.ClusterGenerator, inventory []komodorclient.Cluster) ([]string, error) {
+	var sel labels.Selector
+	if g.Selector != nil {
+		s, err := metav1.LabelSelectorAsSelector(g.Selector)
+		if err != nil {
+			return nil, errors.Wrap(err, errClusterSelector)
+		}
+		sel = s
+	}
+
+	var out []string
+	for _, cl := range inventory {
+		if slices.Contains(g.Names, cl.Name) || (sel != nil && sel.Matches(labels.Set(cl.Tags))) {
+			out = append(out, cl.Name)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out), nil
+}
+
+// Helper: Hash a name to a short suffix
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// Helper: Shorten a name to at most limit characters. Longer names keep a
+// prefix and are suffixed with a hash of the whole name, so distinct names
+// stay distinct.
+func truncate(name string, limit int) string {
+	if len(name) <= limit {
+		return name
+	}
+	h := shortHash(name)
+	return strings.TrimRight(name[:limit-len(h)-1], "-.") + "-" + h
+}
+
+// Helper: Name the RealtimeMonitor of a MonitorSet for a cluster. Cluster
+// names that are not valid in object names are sanitized and suffixed with a
+// hash, so distinct clusters never share a RealtimeMonitor. Names longer than
+// an object name allows are shortened.
+func monitorName(set, cluster string) string {
+	n := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(cluster), "-"), "-")
+	if n != cluster {
+		n = strings.TrimPrefix(n+"-"+shortHash(cluster), "-")
+	}
+	return truncate(set+"-"+n, validation.DNS1123SubdomainMaxLength)
+}
+
+// Helper: The value of the label of the RealtimeMonitors of a MonitorSet.
+// Set names longer than a label value allows are shortened.
+func setLabel(set string) string {
+	return truncate(set, validation.LabelValueMaxLength)
+}
+
+// Helper: Build the RealtimeMonitor of a MonitorSet for a cluster from its
+// template. The MonitorSet controls the monitor.
+func newMonitor(cr *v1beta1.MonitorSet, cluster string) *v1beta1.RealtimeMonitor {
+	p := cr.Spec.ForProvider.Template.DeepCopy()
+	p.Name = fmt.Sprintf("%s (%s)", p.Name, cluster)
+	for i := range p.Sensors {
+		p.Sensors[i].Cluster = cluster
+	}
+
+	return &v1beta1.RealtimeMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            monitorName(cr.GetName(), cluster),
+			Labels:          map[string]string{v1beta1.LabelKeyMonitorSet: setLabel(cr.GetName())},
+			Annotations:     map[string]string{v1beta1.AnnotationKeyCluster: cluster},
+			OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(cr, v1beta1.MonitorSetGroupVersionKind))},
+		},
+		Spec: v1beta1.RealtimeMonitorSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: cr.GetProviderConfigReference()},
+			ForProvider:  *p,
+		},
+	}
+}
+
+// A change to the RealtimeMonitors of a MonitorSet. The existing monitor is
+// nil for monitors to create, the desired one for monitors to delete.
+type change struct {
+	desired  *v1beta1.RealtimeMonitor
+	existing *v1beta1.RealtimeMonitor
+}
+
+// Helper: Plan the changes that turn the existing RealtimeMonitors into the
+// desired ones. Only the fields a MonitorSet sets are compared, so fields
+// defaulted or late initialized on the monitors never count as changes.
+func planChanges(desired, existing []*v1beta1.RealtimeMonitor) []change {
+	byName := make(map[string]*v1beta1.RealtimeMonitor, len(existing))
+	for _, m := range existing {
+		byName[m.GetName()] = m
+	}
+
+	var out []change
+	for _, d := range desired {
+		e, ok := byName[d.GetName()]
+		delete(byName, d.GetName())
+		switch {
+		case !ok:
+			out = append(out, change{desired: d})
+		case !isMonitorUpToDate(d, e):
+			out = append(out, change{desired: d, existing: e})
+		}
+	}
+	for _, e := range existing {
+		if _, ok := byName[e.GetName()]; ok {
+			out = append(out, change{existing: e})
+		}
+	}
+	return out
+}
+
+// Helper: Whether an existing RealtimeMonitor matches the desired one. Fields
+// the monitor late initialized from Komodor, and notification channels it
+// resolved from the template's sinkRefs or sinkSelector, are not compared.
+func isMonitorUpToDate(desired, existing *v1beta1.RealtimeMonitor) bool {
+	want := desired.Spec.ForProvider.DeepCopy()
+	want.LateInitialize(&existing.Spec.ForProvider)
+	if want.NotificationChannels == nil && (want.SinkRefs != nil || want.SinkSelector != nil) {
+		want.NotificationChannels = existing.Spec.ForProvider.NotificationChannels
+	}
+	return reflect.DeepEqual(*want, existing.Spec.ForProvider) &&
+		reflect.DeepEqual(desired.Spec.ProviderConfigReference, existing.Spec.ProviderConfigReference)
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitorset
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	apisv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+const (
+	errNotMonitorSet   = "managed resource is not a MonitorSet custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errNewClient       = "cannot create new Service"
+	errListClusters    = "cannot list clusters in Komodor"
+	errListMonitors    = "cannot list RealtimeMonitors of MonitorSet"
+	errCreateMonitor   = "cannot create RealtimeMonitor"
+	errUpdateMonitor   = "cannot update RealtimeMonitor"
+	errDeleteMonitor   = "cannot delete RealtimeMonitor"
+	errClusterSelector = "cannot parse cluster selector"
+)
+
+// KomodorClient is the subset of the Komodor client a MonitorSet needs.
+type KomodorClient interface {
+	CachedClusters(ctx context.Context) ([]komodorclient.Cluster, error)
+}
+
+// Setup adds a controller that reconciles MonitorSet managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1beta1.MonitorSetGroupKind)
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnecter(&connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			inventory: komodorclient.NewClusterInventory(),
+			clients:   komodorclient.NewClientCache()}),
+		// A MonitorSet has no external resource to name, its RealtimeMonitors
+		// are found by their controller reference.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithManagementPolicies(),
+	}
+
+	if o.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		opts = append(opts, managed.WithChangeLogger(o.ChangeLogOptions.ChangeLogger))
+	}
+
+	if o.MetricOptions != nil {
+		opts = append(opts, managed.WithMetricRecorder(o.MetricOptions.MRMetrics))
+	}
+
+	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1beta1.MonitorSetList{}, o.MetricOptions.PollStateMetricInterval,
+		)
+		if err := mgr.Add(stateMetricsRecorder); err != nil {
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind v1beta1.MonitorSetList")
+		}
+	}
+
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1beta1.MonitorSetGroupVersionKind), opts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1beta1.MonitorSet{}).
+		// Monitors edited or deleted by hand are restored.
+		Owns(&v1beta1.RealtimeMonitor{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube  client.Client
+	usage resource.Tracker
+
+	// inventory caches the Komodor cluster inventory across all clients
+	// this connector creates.
+	inventory *komodorclient.ClusterInventory
+
+	// clients reuses Komodor clients across reconciles.
+	clients *komodorclient.ClientCache
+}
+
+// Connect produces an ExternalClient authenticated with the credentials of
+// the MonitorSet's ProviderConfig.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.MonitorSet)
+	if !ok {
+		return nil, errors.New(errNotMonitorSet)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	client, err := komodorclient.ProviderConfigClient(ctx, c.kube, pc, c.clients, komodorclient.WithClusterInventory(c.inventory))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &external{client: client, kube: c.kube}, nil
+}
+
+// external manages the RealtimeMonitors of a MonitorSet. They are the
+// MonitorSet's external resources.
+type external struct {
+	client KomodorClient
+	kube   client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.MonitorSet)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMonitorSet)
+	}
+
+	existing, err := c.listMonitors(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// A deleted MonitorSet exists until its monitors are deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: len(existing) > 0, ResourceUpToDate: true}, nil
+	}
+
+	desired, err := c.desiredMonitors(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(planChanges(desired, existing)) == 0,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.MonitorSet)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMonitorSet)
+	}
+	return managed.ExternalCreation{}, c.sync(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.MonitorSet)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMonitorSet)
+	}
+	return managed.ExternalUpdate{}, c.sync(ctx, cr)
+}
+
+// Delete deletes the RealtimeMonitors of the MonitorSet, which delete their
+// Komodor monitors according to their own deletion policy.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1beta1.MonitorSet)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotMonitorSet)
+	}
+
+	existing, err := c.listMonitors(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	for _, m := range existing {
+		if err := c.kube.Delete(ctx, m); resource.IgnoreNotFound(err) != nil {
+			return managed.ExternalDelete{}, errors.Wrapf(err, "%s %s", errDeleteMonitor, m.GetName())
+		}
+	}
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) Disconnect(_ context.Context) error {
+	return nil
+}
+
+// Helper: Create, update and prune the RealtimeMonitors of a MonitorSet
+func (c *external) sync(ctx context.Context, cr *v1beta1.MonitorSet) error {
+	existing, err := c.listMonitors(ctx, cr)
+	if err != nil {
+		return err
+	}
+	desired, err := c.desiredMonitors(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	for _, ch := range planChanges(desired, existing) {
+		switch {
+		case ch.existing == nil:
+			if err := c.kube.Create(ctx, ch.desired); err != nil {
+				return errors.Wrapf(err, "%s %s", errCreateMonitor, ch.desired.GetName())
+			}
+		case ch.desired == nil:
+			if err := c.kube.Delete(ctx, ch.existing); resource.IgnoreNotFound(err) != nil {
+				return errors.Wrapf(err, "%s %s", errDeleteMonitor, ch.existing.GetName())
+			}
+		default:
+			ch.existing.Spec.ForProvider = ch.desired.Spec.ForProvider
+			ch.existing.Spec.ProviderConfigReference = ch.desired.Spec.ProviderConfigReference
+			if err := c.kube.Update(ctx, ch.existing); err != nil {
+				return errors.Wrapf(err, "%s %s", errUpdateMonitor, ch.existing.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// Helper: List the RealtimeMonitors a MonitorSet controls. Monitors that
+// merely carry its label are not its own.
+func (c *external) listMonitors(ctx context.Context, cr *v1beta1.MonitorSet) ([]*v1beta1.RealtimeMonitor, error) {
+	l := &v1beta1.RealtimeMonitorList{}
+	if err := c.kube.List(ctx, l, client.MatchingLabels{v1beta1.LabelKeyMonitorSet: setLabel(cr.GetName())}); err != nil {
+		return nil, errors.Wrap(err, errListMonitors)
+	}
+	out := make([]*v1beta1.RealtimeMonitor, 0, len(l.Items))
+	for i := range l.Items {
+		if metav1.IsControlledBy(&l.Items[i], cr) {
+			out = append(out, &l.Items[i])
+		}
+	}
+	return out, nil
+}
+
+// Helper: Build the RealtimeMonitors a MonitorSet should have, one for each
+// Komodor cluster it generates, and record the clusters in its status
+func (c *external) desiredMonitors(ctx context.Context, cr *v1beta1.MonitorSet) ([]*v1beta1.RealtimeMonitor, error) {
+	inventory, err := c.client.CachedClusters(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errListClusters)
+	}
+	clusters, err := generateClusters(&cr.Spec.ForProvider.Clusters, inventory)
+	if err != nil {
+		return nil, err
+	}
+	cr.Status.AtProvider.Clusters = clusters
+
+	out := make([]*v1beta1.RealtimeMonitor, 0, len(clusters))
+	for _, cluster := range clusters {
+		out = append(out, newMonitor(cr, cluster))
+	}
+	return out, nil
+}
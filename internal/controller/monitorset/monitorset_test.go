@@ -0,0 +1,330 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitorset
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+type mockClient struct {
+	clusters []komodorclient.Cluster
+	err      error
+}
+
+func (m *mockClient) CachedClusters(_ context.Context) ([]komodorclient.Cluster, error) {
+	return m.clusters, m.err
+}
+
+func monitorSet() *v1beta1.MonitorSet {
+	return &v1beta1.MonitorSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "availability", UID: "set-uid"},
+		Spec: v1beta1.MonitorSetSpec{
+			ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "default"}},
+			ForProvider: v1beta1.MonitorSetParameters{
+				Clusters: v1beta1.ClusterGenerator{
+					Names:    []string{"staging"},
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				},
+				Template: v1beta1.RealtimeMonitorParameters{
+					Name:    "Availability",
+					Sensors: []v1beta1.Sensor{{Namespaces: []string{"default"}}},
+					Sinks:   v1beta1.Sinks{Slack: []string{"alerts"}},
+					Active:  true,
+					MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+						Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerateClusters(t *testing.T) {
+	inventory := []komodorclient.Cluster{
+		{Name: "prod-us", Tags: map[string]string{"env": "prod"}},
+		{Name: "prod-eu", Tags: map[string]string{"env": "prod"}},
+		{Name: "staging", Tags: map[string]string{"env": "staging"}},
+		{Name: "dev", Tags: map[string]string{"env": "dev"}},
+	}
+
+	cases := map[string]struct {
+		reason string
+		g      v1beta1.ClusterGenerator
+		want   []string
+		err    error
+	}{
+		"Names": {
+			reason: "Named clusters should be generated only if they are onboarded to Komodor.",
+			g:      v1beta1.ClusterGenerator{Names: []string{"staging", "missing"}},
+			want:   []string{"staging"},
+		},
+		"Selector": {
+			reason: "Clusters whose tags match the selector should be generated.",
+			g:      v1beta1.ClusterGenerator{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+			want:   []string{"prod-eu", "prod-us"},
+		},
+		"NamesAndSelector": {
+			reason: "Named and selected clusters should be generated once each.",
+			g:      v1beta1.ClusterGenerator{Names: []string{"prod-us", "dev"}, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}},
+			want:   []string{"dev", "prod-eu", "prod-us"},
+		},
+		"EmptySelector": {
+			reason: "An empty selector should generate every cluster.",
+			g:      v1beta1.ClusterGenerator{Selector: &metav1.LabelSelector{}},
+			want:   []string{"dev", "prod-eu", "prod-us", "staging"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := generateClusters(&tc.g, inventory)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ngenerateClusters(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ngenerateClusters(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMonitorName(t *testing.T) {
+	cases := map[string]struct {
+		set     string
+		cluster string
+		want    string
+	}{
+		"Valid":   {set: "set", cluster: "prod-us", want: "set-prod-us"},
+		"Invalid": {set: "set", cluster: "Prod_US", want: "set-prod-us-df28dcef"},
+		"TooLong": {set: strings.Repeat("a", 250), cluster: "prod-us", want: strings.Repeat("a", 244) + "-4d9bb4d9"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := monitorName(tc.set, tc.cluster); got != tc.want {
+				t.Errorf("monitorName(%q, %q): want %q, got %q", tc.set, tc.cluster, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSetLabel(t *testing.T) {
+	cases := map[string]struct {
+		set  string
+		want string
+	}{
+		"Short":   {set: "availability", want: "availability"},
+		"TooLong": {set: strings.Repeat("a", 70), want: strings.Repeat("a", 54) + "-6bd5e503"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := setLabel(tc.set); got != tc.want {
+				t.Errorf("setLabel(%q): want %q, got %q", tc.set, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	inventory := []komodorclient.Cluster{
+		{Name: "prod-us", Tags: map[string]string{"env": "prod"}},
+		{Name: "staging"},
+	}
+
+	listing := func(ms ...*v1beta1.RealtimeMonitor) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			for _, m := range ms {
+				obj.(*v1beta1.RealtimeMonitorList).Items = append(obj.(*v1beta1.RealtimeMonitorList).Items, *m)
+			}
+			return nil
+		}
+	}
+	edited := newMonitor(monitorSet(), "staging")
+	edited.Spec.ForProvider.Active = false
+	initialized := newMonitor(monitorSet(), "staging")
+	initialized.Spec.ForProvider.Availability.MinAvailable = ptr.To(intstr.FromString("100%"))
+	initialized.Spec.ForProvider.Sensors[0].Services = []string{"checkout"}
+	initialized.Spec.ForProvider.SinksOptions = map[string][]string{"notifyOn": {"OOMKilled"}}
+	deleted := monitorSet()
+	deleted.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+
+	type want struct {
+		o        managed.ExternalObservation
+		clusters []string
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1beta1.MonitorSet
+		client *mockClient
+		list   test.MockListFn
+		want   want
+	}{
+		"UpToDate": {
+			reason: "A MonitorSet whose monitors match its template and clusters should be up to date.",
+			cr:     monitorSet(),
+			client: &mockClient{clusters: inventory},
+			list:   listing(newMonitor(monitorSet(), "prod-us"), newMonitor(monitorSet(), "staging")),
+			want: want{
+				o:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				clusters: []string{"prod-us", "staging"},
+			},
+		},
+		"ClusterJoined": {
+			reason: "A MonitorSet missing the monitor of a cluster that joined Komodor should not be up to date.",
+			cr:     monitorSet(),
+			client: &mockClient{clusters: inventory},
+			list:   listing(newMonitor(monitorSet(), "staging")),
+			want: want{
+				o:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				clusters: []string{"prod-us", "staging"},
+			},
+		},
+		"ClusterLeft": {
+			reason: "A MonitorSet with the monitor of a cluster that left Komodor should not be up to date.",
+			cr:     monitorSet(),
+			client: &mockClient{clusters: inventory[1:]},
+			list:   listing(newMonitor(monitorSet(), "prod-us"), newMonitor(monitorSet(), "staging")),
+			want: want{
+				o:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				clusters: []string{"staging"},
+			},
+		},
+		"MonitorEdited": {
+			reason: "A MonitorSet whose monitor differs from its template should not be up to date.",
+			cr:     monitorSet(),
+			client: &mockClient{clusters: inventory[1:]},
+			list:   listing(edited),
+			want: want{
+				o:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				clusters: []string{"staging"},
+			},
+		},
+		"MonitorLateInitialized": {
+			reason: "Fields a monitor late initialized from Komodor should not count as differences from the template.",
+			cr:     monitorSet(),
+			client: &mockClient{clusters: inventory[1:]},
+			list:   listing(initialized),
+			want: want{
+				o:        managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				clusters: []string{"staging"},
+			},
+		},
+		"Deleted": {
+			reason: "A deleted MonitorSet should exist until its monitors are deleted.",
+			cr:     deleted,
+			client: &mockClient{},
+			list:   listing(newMonitor(monitorSet(), "staging")),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ListClustersError": {
+			reason: "Errors listing Komodor clusters should be returned.",
+			cr:     monitorSet(),
+			client: &mockClient{err: errBoom},
+			list:   listing(),
+			want: want{
+				err: errors.Wrap(errBoom, errListClusters),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, kube: &test.MockClient{MockList: tc.list}}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.clusters, tc.cr.Status.AtProvider.Clusters); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want clusters, +got clusters:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	inventory := []komodorclient.Cluster{{Name: "prod-us"}, {Name: "staging"}}
+	cr := monitorSet()
+	cr.Spec.ForProvider.Clusters = v1beta1.ClusterGenerator{Names: []string{"prod-us", "staging"}}
+
+	stale := newMonitor(cr, "staging")
+	stale.Spec.ForProvider.Active = false
+	left := newMonitor(cr, "dev")
+	// A monitor that carries the label of the set but is controlled by
+	// another object must not be pruned.
+	foreign := newMonitor(cr, "foreign")
+	foreign.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid", Controller: ptr.To(true)}})
+
+	var created, updated, deleted []string
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			obj.(*v1beta1.RealtimeMonitorList).Items = []v1beta1.RealtimeMonitor{*stale, *left, *foreign}
+			return nil
+		},
+		MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			if !metav1.IsControlledBy(obj, cr) {
+				t.Errorf("e.Update(...): monitor %s is not controlled by its set", obj.GetName())
+			}
+			created = append(created, obj.GetName())
+			return nil
+		},
+		MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+			if !obj.(*v1beta1.RealtimeMonitor).Spec.ForProvider.Active {
+				t.Errorf("e.Update(...): monitor %s was not updated from the template", obj.GetName())
+			}
+			updated = append(updated, obj.GetName())
+			return nil
+		},
+		MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+			deleted = append(deleted, obj.GetName())
+			return nil
+		},
+	}
+
+	e := &external{client: &mockClient{clusters: inventory}, kube: kube}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): %v", err)
+	}
+	want := map[string][]string{"created": {"availability-prod-us"}, "updated": {"availability-staging"}, "deleted": {"availability-dev"}}
+	got := map[string][]string{"created": created, "updated": updated, "deleted": deleted}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Update(...): -want, +got:\n%s", diff)
+	}
+}
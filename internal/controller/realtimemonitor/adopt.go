@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package realtimemonitor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	meta "github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+// Helper: Find the existing monitors a RealtimeMonitor may adopt
+func adoptionCandidates(spec *v1beta1.RealtimeMonitorParameters, monitors []komodorclient.Monitor) []komodorclient.Monitor {
+	var candidates []komodorclient.Monitor
+	for _, m := range monitors {
+		if !m.IsDeleted && m.Name == spec.Name && m.Type == spec.Type() {
+			candidates = append(candidates, m)
+		}
+	}
+	return candidates
+}
+
+// Helper: Adopt the only existing monitor matching the spec by name and type.
+// The adopted monitor's ID is reported as late initialized so the managed
+// reconciler persists the external name.
+func (c *external) adoptMonitor(ctx context.Context, cr *v1beta1.RealtimeMonitor) (managed.ExternalObservation, error) {
+	logger := log.FromContext(ctx)
+
+	monitors, err := c.client.ListMonitors(ctx)
+	if err != nil {
+		cr.SetConditions(xpv1.ReconcileError(errors.Wrap(err, "cannot list monitors in Komodor")))
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot list monitors in Komodor to adopt")
+	}
+
+	candidates := adoptionCandidates(&cr.Spec.ForProvider, monitors)
+	switch len(candidates) {
+	case 0:
+		logger.Info("No existing monitor to adopt, resource does not exist",
+			"monitorName", cr.Spec.ForProvider.Name,
+			"monitorType", cr.Spec.ForProvider.Type())
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	case 1:
+	default:
+		ids := make([]string, 0, len(candidates))
+		for _, m := range candidates {
+			ids = append(ids, m.ID)
+		}
+		err := errors.Errorf("cannot adopt monitor: %d Komodor monitors named %q of type %q exist (%s), set the crossplane.io/external-name annotation to the ID of the one to manage",
+			len(candidates), cr.Spec.ForProvider.Name, cr.Spec.ForProvider.Type(), strings.Join(ids, ", "))
+		cr.SetConditions(xpv1.ReconcileError(err))
+		return managed.ExternalObservation{}, err
+	}
+
+	monitor := &candidates[0]
+	logger.Info("Adopting existing monitor", "monitorID", monitor.ID, "monitorName", monitor.Name)
+	meta.SetExternalName(cr, monitor.ID)
+
+	desired, secrets, err := c.resolveDesired(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	lateInitialize(cr, desired, monitor)
+	drift := c.observeDrift(cr, desired, monitor, secrets)
+	resourceUpToDate := len(drift) == 0
+	updateStatusFromMonitor(cr, monitor, secrets)
+	c.setObserveConditions(cr, resourceUpToDate, monitor.ID, logger)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        resourceUpToDate,
+		ResourceLateInitialized: true,
+		Diff:                    strings.Join(drift, ", "),
+	}, nil
+}
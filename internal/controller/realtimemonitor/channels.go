@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package realtimemonitor
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+)
+
+// errFmtChannelNotFound is reported while a referenced NotificationChannel
+// does not exist. The monitor is reconciled again once it is created.
+const errFmtChannelNotFound = "NotificationChannel %q not found, create it or remove it from spec.forProvider.notificationChannels"
+
+// Helper: Build the parameters to apply to Komodor, adding the sinks and sink
+// options of the monitor's NotificationChannels to its own
+func (c *external) desiredParameters(ctx context.Context, cr *v1beta1.RealtimeMonitor) (*v1beta1.RealtimeMonitorParameters, error) {
+	p := cr.Spec.ForProvider.DeepCopy()
+	for _, name := range p.NotificationChannels {
+		nc := &v1beta1.NotificationChannel{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: name}, nc); err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, errors.Errorf(errFmtChannelNotFound, name)
+			}
+			return nil, errors.Wrapf(err, "cannot get NotificationChannel %q", name)
+		}
+		mergeSinks(&p.Sinks, &nc.Spec.ForProvider.Sinks)
+		p.SinksOptions = mergeSinksOptions(p.SinksOptions, nc.Spec.ForProvider.SinksOptions)
+	}
+	return p, nil
+}
+
+// Helper: Build the parameters to apply to Komodor and resolve their sink
+// secrets, reporting failures on the resource
+func (c *external) resolveDesired(ctx context.Context, cr *v1beta1.RealtimeMonitor) (*v1beta1.RealtimeMonitorParameters, *sinkSecrets, error) {
+	desired, err := c.desiredParameters(ctx, cr)
+	if err != nil {
+		cr.SetConditions(xpv1.ReconcileError(err))
+		return nil, nil, err
+	}
+	secrets, err := c.resolveSinkSecrets(ctx, &desired.Sinks, []byte(cr.GetUID()))
+	if err != nil {
+		cr.SetConditions(xpv1.ReconcileError(err))
+		return nil, nil, err
+	}
+	return desired, secrets, nil
+}
+
+// Helper: Add the sinks of src missing from dst to dst
+func mergeSinks(dst, src *v1beta1.Sinks) {
+	dst.Slack = appendMissing(dst.Slack, src.Slack)
+	dst.SlackSecretRefs = appendMissing(dst.SlackSecretRefs, src.SlackSecretRefs)
+	dst.Teams = appendMissing(dst.Teams, src.Teams)
+	dst.TeamsSecretRefs = appendMissing(dst.TeamsSecretRefs, src.TeamsSecretRefs)
+	dst.Opsgenie = appendMissing(dst.Opsgenie, src.Opsgenie)
+	dst.OpsgenieSecretRefs = appendMissing(dst.OpsgenieSecretRefs, src.OpsgenieSecretRefs)
+	dst.PagerDuty = appendMissing(dst.PagerDuty, src.PagerDuty)
+	dst.GenericWebhook = appendMissing(dst.GenericWebhook, src.GenericWebhook)
+	dst.GenericWebhookSecretRefs = appendMissing(dst.GenericWebhookSecretRefs, src.GenericWebhookSecretRefs)
+}
+
+// Helper: Add the options of src missing from dst to dst
+func mergeSinksOptions(dst, src map[string][]string) map[string][]string {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[string][]string, len(src))
+	}
+	for k, v := range src {
+		dst[k] = appendMissing(dst[k], v)
+	}
+	return dst
+}
+
+// Helper: Append the elements of src that dst does not contain to dst
+func appendMissing[T any](dst, src []T) []T {
+	for _, v := range src {
+		if !slices.ContainsFunc(dst, func(d T) bool { return reflect.DeepEqual(d, v) }) {
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// monitorsForChannel maps a NotificationChannel to the RealtimeMonitors that
+// reference it or may select it, so they are updated when it changes.
+func monitorsForChannel(kube client.Reader, log logging.Logger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		l := &v1beta1.RealtimeMonitorList{}
+		if err := kube.List(ctx, l); err != nil {
+			log.Info("Cannot list RealtimeMonitors notifying NotificationChannel", "channel", o.GetName(), "error", err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, m := range l.Items {
+			p := m.Spec.ForProvider
+			referenced := slices.Contains(p.NotificationChannels, o.GetName()) ||
+				slices.ContainsFunc(p.SinkRefs, func(r xpv1.Reference) bool { return r.Name == o.GetName() })
+			unresolved := p.SinkSelector != nil && len(p.NotificationChannels) == 0
+			if referenced || unresolved {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: m.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
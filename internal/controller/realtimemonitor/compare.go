@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package realtimemonitor
+
+import (
+	"cmp"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+// Helper: List the fields of a spec, relative to spec.forProvider, that differ
+// from a monitor. The comparison is semantic rather than structural, so that
+// monitors Komodor returns in a different but equivalent form never count as
+// drift:
+//
+//   - Fields Komodor returns that the API does not model are ignored.
+//   - Variables the spec leaves unset are left to Komodor and ignored.
+//   - Sensors, sink lists and sink option values are compared as sets, and
+//     empty lists and objects equal unset ones.
+//   - Numbers are compared numerically, so a minAvailable of 2 equals "2".
+//
+// Spec sensors and sinks pass through the Komodor model first to drop their
+// cluster and secret references. Sink secrets are compared by the hash of
+// those last sent to Komodor, observed sinks are compared with the secret
+// values removed.
+func monitorDrift(spec *v1beta1.RealtimeMonitorParameters, monitor *komodorclient.Monitor, secrets *sinkSecrets, appliedSecretsHash string) []string {
+	var drift []string
+	if spec.Name != monitor.Name {
+		drift = append(drift, "name")
+	}
+	if spec.Active != monitor.Active {
+		drift = append(drift, "active")
+	}
+	if spec.Type() != monitor.Type {
+		drift = append(drift, "type")
+	} else {
+		drift = append(drift, variablesDrift(typeField(spec.Type()), spec.Variables(), variablesFromKomodor(monitor.Variables))...)
+	}
+	drift = append(drift, sensorsDrift(sensorsFromKomodor(sensorsToKomodor(spec.Sensors)), sensorsFromKomodor(monitor.Sensors))...)
+	drift = append(drift, sinksDrift(sinksFromKomodor(sinksToKomodor(&spec.Sinks)), secrets.redact(sinksFromKomodor(monitor.Sinks)))...)
+	if appliedSecretsHash != secrets.hash() {
+		drift = append(drift, "sinks.secrets")
+	}
+	drift = append(drift, sinksOptionsDrift(spec.SinksOptions, monitor.SinksOptions)...)
+	return drift
+}
+
+// Helper: The spec field holding the variables of a monitor type
+func typeField(monitorType string) string {
+	if monitorType == v1beta1.MonitorTypePVC {
+		return "pvc"
+	}
+	return monitorType
+}
+
+// Helper: List the desired variables that differ from the observed ones,
+// ignoring those the desired variables leave unset
+func variablesDrift(prefix string, desired, observed *v1beta1.Variables) []string {
+	if desired == nil {
+		return nil
+	}
+	if observed == nil {
+		observed = &v1beta1.Variables{}
+	}
+	var drift []string
+	for _, f := range []struct {
+		name  string
+		equal bool
+	}{
+		{name: "duration", equal: unsetOrEqual(desired.Duration, observed.Duration)},
+		{name: "minAvailable", equal: desired.MinAvailable == nil || observed.MinAvailable != nil && normalizeIntOrString(*desired.MinAvailable) == normalizeIntOrString(*observed.MinAvailable)},
+		{name: "categories", equal: desired.Categories == nil || slices.Equal(normalizeSet(desired.Categories), normalizeSet(observed.Categories))},
+		{name: "reasons", equal: desired.Reasons == nil || slices.Equal(normalizeSet(desired.Reasons), normalizeSet(observed.Reasons))},
+		{name: "nodeCreationThreshold", equal: desired.NodeCreationThreshold == "" || desired.NodeCreationThreshold == observed.NodeCreationThreshold},
+		{name: "cronJobCondition", equal: desired.CronJobCondition == "" || desired.CronJobCondition == observed.CronJobCondition},
+		{name: "resolveAfter", equal: unsetOrEqual(desired.ResolveAfter, observed.ResolveAfter)},
+		{name: "ignoreAfter", equal: unsetOrEqual(desired.IgnoreAfter, observed.IgnoreAfter)},
+	} {
+		if !f.equal {
+			drift = append(drift, prefix+"."+f.name)
+		}
+	}
+	return drift
+}
+
+// Helper: List the clusters whose desired sensors differ from the observed
+// ones, as sensors[<cluster>]
+func sensorsDrift(desired, observed []v1beta1.Sensor) []string {
+	byCluster := func(in []v1beta1.Sensor) map[string][]v1beta1.Sensor {
+		out := map[string][]v1beta1.Sensor{}
+		for _, s := range in {
+			out[s.Cluster] = append(out[s.Cluster], s)
+		}
+		return out
+	}
+	d, o := byCluster(desired), byCluster(observed)
+
+	clusters := make([]string, 0, len(d)+len(o))
+	for c := range d {
+		clusters = append(clusters, c)
+	}
+	for c := range o {
+		clusters = append(clusters, c)
+	}
+
+	var drift []string
+	for _, c := range normalizeSet(clusters) {
+		if !reflect.DeepEqual(normalizeSensors(d[c]), normalizeSensors(o[c])) {
+			drift = append(drift, "sensors["+c+"]")
+		}
+	}
+	return drift
+}
+
+// Helper: List the kinds of sinks whose desired sinks differ from the
+// observed ones
+func sinksDrift(desired, observed *v1beta1.Sinks) []string {
+	d, o := normalizeSinks(desired), normalizeSinks(observed)
+	if d == nil {
+		d = &v1beta1.Sinks{}
+	}
+	if o == nil {
+		o = &v1beta1.Sinks{}
+	}
+	var drift []string
+	for _, f := range []struct {
+		name  string
+		equal bool
+	}{
+		{name: "slack", equal: slices.Equal(d.Slack, o.Slack)},
+		{name: "teams", equal: slices.Equal(d.Teams, o.Teams)},
+		{name: "opsgenie", equal: slices.Equal(d.Opsgenie, o.Opsgenie)},
+		{name: "pagerDuty", equal: reflect.DeepEqual(d.PagerDuty, o.PagerDuty)},
+		{name: "genericWebhook", equal: slices.Equal(d.GenericWebhook, o.GenericWebhook)},
+	} {
+		if !f.equal {
+			drift = append(drift, "sinks."+f.name)
+		}
+	}
+	return drift
+}
+
+// Helper: List the desired sink options that differ from the observed ones
+func sinksOptionsDrift(desired, observed map[string][]string) []string {
+	d, o := normalizeSinksOptions(desired), normalizeSinksOptions(observed)
+	keys := make([]string, 0, len(d)+len(o))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	for k := range o {
+		keys = append(keys, k)
+	}
+
+	var drift []string
+	for _, k := range normalizeSet(keys) {
+		if !slices.Equal(d[k], o[k]) {
+			drift = append(drift, "sinksOptions."+k)
+		}
+	}
+	return drift
+}
+
+// Helper: Whether desired is unset or points to the value observed points to
+func unsetOrEqual[T comparable](desired, observed *T) bool {
+	return desired == nil || observed != nil && *desired == *observed
+}
+
+// Helper: Represent numeric strings as numbers, so 2 and "2" compare equal
+func normalizeIntOrString(v intstr.IntOrString) intstr.IntOrString {
+	if v.Type == intstr.String {
+		if i, err := strconv.ParseInt(v.StrVal, 10, 32); err == nil {
+			return intstr.FromInt32(int32(i))
+		}
+	}
+	return v
+}
+
+// Helper: Sort and deduplicate a list compared as a set, nil if it is empty
+func normalizeSet[T cmp.Ordered](in []T) []T {
+	if len(in) == 0 {
+		return nil
+	}
+	out := slices.Clone(in)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// Helper: Normalize sensors to be compared as a set
+func normalizeSensors(in []v1beta1.Sensor) []v1beta1.Sensor {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]v1beta1.Sensor, 0, len(in))
+	for _, s := range in {
+		n := v1beta1.Sensor{
+			Cluster:    s.Cluster,
+			Namespaces: normalizeSet(s.Namespaces),
+			Services:   normalizeSet(s.Services),
+			Conditions: normalizeSet(s.Conditions),
+			Labels:     normalizeSet(s.Labels),
+		}
+		if s.Exclude != nil && (len(s.Exclude.Namespaces) > 0 || len(s.Exclude.Services) > 0) {
+			n.Exclude = &v1beta1.SensorScope{
+				Namespaces: normalizeSet(s.Exclude.Namespaces),
+				Services:   normalizeSet(s.Exclude.Services),
+			}
+		}
+		out = append(out, n)
+	}
+	// Sensors have no natural order, order them by their encoding.
+	key := func(s v1beta1.Sensor) string {
+		b, _ := json.Marshal(s) //nolint:errchkjson // Sensors always encode.
+		return string(b)
+	}
+	slices.SortFunc(out, func(a, b v1beta1.Sensor) int { return cmp.Compare(key(a), key(b)) })
+	return slices.CompactFunc(out, func(a, b v1beta1.Sensor) bool { return key(a) == key(b) })
+}
+
+// Helper: Normalize sinks to compare each kind of sink as a set, nil if there
+// are none
+func normalizeSinks(in *v1beta1.Sinks) *v1beta1.Sinks {
+	if in == nil {
+		return nil
+	}
+	out := &v1beta1.Sinks{
+		Slack:          normalizeSet(in.Slack),
+		Teams:          normalizeSet(in.Teams),
+		Opsgenie:       normalizeSet(in.Opsgenie),
+		GenericWebhook: normalizeSet(in.GenericWebhook),
+	}
+	if len(in.PagerDuty) > 0 {
+		out.PagerDuty = slices.Clone(in.PagerDuty)
+		slices.SortFunc(out.PagerDuty, func(a, b v1beta1.PagerDutySink) int {
+			return cmp.Or(
+				cmp.Compare(a.Channel, b.Channel),
+				cmp.Compare(a.IntegrationKey, b.IntegrationKey),
+				cmp.Compare(a.PagerDutyAccountName, b.PagerDutyAccountName),
+			)
+		})
+		out.PagerDuty = slices.CompactFunc(out.PagerDuty, func(a, b v1beta1.PagerDutySink) bool { return reflect.DeepEqual(a, b) })
+	}
+	if reflect.DeepEqual(out, &v1beta1.Sinks{}) {
+		return nil
+	}
+	return out
+}
+
+// Helper: Normalize sink options to compare their values as sets, dropping
+// options without values; nil if there are none
+func normalizeSinksOptions(in map[string][]string) map[string][]string {
+	out := map[string][]string{}
+	for k, v := range in {
+		if len(v) > 0 {
+			out[k] = normalizeSet(v)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package realtimemonitor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+// fixture reads a Komodor monitor response from testdata.
+func fixture(t *testing.T, name string) *komodorclient.Monitor {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("cannot read fixture %s: %v", name, err)
+	}
+	m := &komodorclient.Monitor{}
+	if err := json.Unmarshal(b, m); err != nil {
+		t.Fatalf("cannot decode fixture %s: %v", name, err)
+	}
+	return m
+}
+
+// availabilitySpec matches testdata/availability-monitor.json.
+func availabilitySpec() *v1beta1.RealtimeMonitorParameters {
+	return &v1beta1.RealtimeMonitorParameters{
+		Name:    "checkout availability",
+		Active:  true,
+		Sensors: []v1beta1.Sensor{{Cluster: "prod-eu", Namespaces: []string{"checkout", "payments"}}},
+		Sinks:   v1beta1.Sinks{Slack: []string{"checkout-alerts"}},
+		MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+			Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30), MinAvailable: ptr.To(intstr.FromInt32(2))},
+		},
+		SinksOptions: map[string][]string{"notifyOn": {"BackOff", "Image", "OOMKilled"}},
+	}
+}
+
+// nodeSpec matches testdata/node-monitor.json.
+func nodeSpec() *v1beta1.RealtimeMonitorParameters {
+	return &v1beta1.RealtimeMonitorParameters{
+		Name:   "node health",
+		Active: true,
+		Sensors: []v1beta1.Sensor{
+			{Cluster: "prod-eu", Conditions: []string{"DiskPressure", "MemoryPressure", "Ready"}},
+			{Cluster: "prod-us", Conditions: []string{"Ready", "MemoryPressure", "DiskPressure"}},
+		},
+		Sinks: v1beta1.Sinks{
+			Slack:     []string{"infra-alerts", "infra-oncall"},
+			PagerDuty: []v1beta1.PagerDutySink{{Channel: "infra", IntegrationKey: "b9c8d7e6f5a4", PagerDutyAccountName: "acme"}},
+		},
+		MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+			Node: &v1beta1.NodeMonitor{Duration: ptr.To(60), NodeCreationThreshold: "5m", ResolveAfter: ptr.To(3600)},
+		},
+	}
+}
+
+func TestMonitorDrift(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		fixture string
+		spec    func() *v1beta1.RealtimeMonitorParameters
+		want    []string
+	}{
+		"ServerDefaults": {
+			reason:  "Variables Komodor populates but the spec leaves unset, empty lists and objects, and unknown fields should not count as drift.",
+			fixture: "availability-monitor.json",
+			spec:    availabilitySpec,
+		},
+		"NumbersComparedNumerically": {
+			reason:  "A duration Komodor returns as 30.0 and a minAvailable it returns as \"2\" should equal the spec's 30 and 2.",
+			fixture: "availability-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := availabilitySpec()
+				p.Availability.MinAvailable = ptr.To(intstr.FromString("2"))
+				return p
+			},
+		},
+		"Reordered": {
+			reason:  "Sensors, their conditions and sink lists Komodor returns in another order should not count as drift.",
+			fixture: "node-monitor.json",
+			spec:    nodeSpec,
+		},
+		"DurationChanged": {
+			reason:  "A variable the spec sets to another value should count as drift.",
+			fixture: "availability-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := availabilitySpec()
+				p.Availability.Duration = ptr.To(45)
+				return p
+			},
+			want: []string{"availability.duration"},
+		},
+		"VariableSetOnlyInSpec": {
+			reason:  "A variable the spec sets that Komodor does not return should count as drift.",
+			fixture: "node-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := nodeSpec()
+				p.Node.IgnoreAfter = ptr.To(7200)
+				return p
+			},
+			want: []string{"node.ignoreAfter"},
+		},
+		"CategoriesChanged": {
+			reason:  "Categories the spec sets should be compared with those Komodor populated.",
+			fixture: "availability-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := availabilitySpec()
+				p.Availability.Categories = []string{"OOMKilled"}
+				return p
+			},
+			want: []string{"availability.categories"},
+		},
+		"SensorRemoved": {
+			reason:  "A sensor missing from the spec should count as drift.",
+			fixture: "node-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := nodeSpec()
+				p.Sensors = p.Sensors[:1]
+				return p
+			},
+			want: []string{"sensors[prod-us]"},
+		},
+		"NamespaceAdded": {
+			reason:  "A namespace added to a sensor should count as drift.",
+			fixture: "availability-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := availabilitySpec()
+				p.Sensors[0].Namespaces = append(p.Sensors[0].Namespaces, "cart")
+				return p
+			},
+			want: []string{"sensors[prod-eu]"},
+		},
+		"SinkAdded": {
+			reason:  "A sink added to the spec should count as drift.",
+			fixture: "availability-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := availabilitySpec()
+				p.Sinks.Teams = []string{"checkout"}
+				return p
+			},
+			want: []string{"sinks.teams"},
+		},
+		"SinksOptionValueRemoved": {
+			reason:  "A value removed from a sink option should count as drift.",
+			fixture: "availability-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := availabilitySpec()
+				p.SinksOptions["notifyOn"] = []string{"OOMKilled", "Image"}
+				return p
+			},
+			want: []string{"sinksOptions.notifyOn"},
+		},
+		"PagerDutyKeyChanged": {
+			reason:  "A changed PagerDuty integration key should count as drift.",
+			fixture: "node-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := nodeSpec()
+				p.Sinks.PagerDuty[0].IntegrationKey = "0000"
+				return p
+			},
+			want: []string{"sinks.pagerDuty"},
+		},
+		"TypeChanged": {
+			reason:  "A monitor of another type should count as drift.",
+			fixture: "availability-monitor.json",
+			spec: func() *v1beta1.RealtimeMonitorParameters {
+				p := availabilitySpec()
+				p.Availability = nil
+				p.Deploy = &v1beta1.DeployMonitor{}
+				return p
+			},
+			want: []string{"type"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := monitorDrift(tc.spec(), fixture(t, tc.fixture), &sinkSecrets{}, "")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nmonitorDrift(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -29,16 +29,23 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	"github.com/crossplane/provider-komodor/apis/komodor/v1alpha1"
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
 	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+	"github.com/crossplane/provider-komodor/internal/tracing"
 )
 
 // Helper: Validate clusters
-func (c *external) validateClusters(ctx context.Context, specSensors []map[string]interface{}, cr *v1alpha1.RealtimeMonitor, logger logr.Logger) error {
+func (c *external) validateClusters(ctx context.Context, specSensors []v1beta1.Sensor, cr *v1beta1.RealtimeMonitor, logger logr.Logger) (err error) {
+	ctx, span := tracer.Start(ctx, "RealtimeMonitor.ValidateClusters")
+	defer func() { tracing.End(span, err) }()
+
+	// Several sensors commonly target the same cluster, validate each name once.
 	var clusterNames []string
+	seen := map[string]bool{}
 	for _, sensor := range specSensors {
-		if cluster, ok := sensor["cluster"].(string); ok && cluster != "" {
-			clusterNames = append(clusterNames, cluster)
+		if sensor.Cluster != "" && !seen[sensor.Cluster] {
+			seen[sensor.Cluster] = true
+			clusterNames = append(clusterNames, sensor.Cluster)
 		}
 	}
 
@@ -52,6 +59,9 @@ func (c *external) validateClusters(ctx context.Context, specSensors []map[strin
 		}
 
 		if !clusterExists {
+			// The cached inventory may predate the cluster being onboarded,
+			// make sure the next attempt sees a fresh one.
+			c.client.InvalidateClusters()
 			errorMsg := fmt.Sprintf("cluster '%s' does not exist in Komodor. Monitors for non-existent clusters will not be visible in the Komodor UI", clusterName)
 			logger.Error(errors.New(errorMsg), "Cluster validation failed", "clusterName", clusterName)
 			cr.SetConditions(xpv1.ReconcileError(errors.New(errorMsg)))
@@ -64,53 +74,58 @@ func (c *external) validateClusters(ctx context.Context, specSensors []map[strin
 }
 
 // Helper: Create monitor in Komodor
-func (c *external) createMonitorInKomodor(ctx context.Context, specData *specData, cr *v1alpha1.RealtimeMonitor, logger logr.Logger) (*komodorclient.Monitor, error) {
-	monitor := &komodorclient.Monitor{
-		Name:         cr.Spec.ForProvider.Name,
-		Sensors:      specData.sensors,
-		Sinks:        specData.sinks,
-		Active:       cr.Spec.ForProvider.Active,
-		Type:         cr.Spec.ForProvider.Type,
-		Variables:    specData.variables,
-		SinksOptions: cr.Spec.ForProvider.SinksOptions,
+func (c *external) createMonitorInKomodor(ctx context.Context, cr *v1beta1.RealtimeMonitor, logger logr.Logger) (*komodorclient.Monitor, *sinkSecrets, error) {
+	desired, secrets, err := c.resolveDesired(ctx, cr)
+	if err != nil {
+		return nil, nil, err
 	}
+	monitor := generateMonitor(desired, secrets)
 
 	logger.Info("Sending create request to Komodor",
 		"monitorName", monitor.Name,
 		"monitorType", monitor.Type)
 
 	created, err := c.client.CreateMonitor(ctx, monitor)
+	if komodorclient.IsConflict(err) {
+		err = errors.Wrapf(err, "a monitor named %q already exists in Komodor", monitor.Name)
+	}
 	if err != nil {
 		logger.Error(err, "Failed to create monitor in Komodor", "monitorName", monitor.Name)
 		cr.SetConditions(xpv1.ReconcileError(errors.Wrap(err, "cannot create monitor in Komodor")))
-		return nil, errors.Wrap(err, "cannot create monitor in Komodor")
+		return nil, nil, errors.Wrap(err, "cannot create monitor in Komodor")
 	}
 
 	logger.Info("Successfully created monitor in Komodor",
 		"monitorID", created.ID,
 		"monitorName", created.Name)
 
-	return created, nil
+	return created, secrets, nil
 }
 
 // Helper: Update resource from created monitor
-func (c *external) updateResourceFromCreatedMonitor(cr *v1alpha1.RealtimeMonitor, created *komodorclient.Monitor, logger logr.Logger) {
+func (c *external) updateResourceFromCreatedMonitor(cr *v1beta1.RealtimeMonitor, created *komodorclient.Monitor, secrets *sinkSecrets, logger logr.Logger) {
 	// Set external-name to the Komodor monitor ID
 	meta.SetExternalName(cr, created.ID)
 
-	if err := updateStatusFromMonitor(cr, created); err != nil {
-		logger.Error(err, "Failed to update status from created monitor")
-		return
-	}
+	updateStatusFromMonitor(cr, created, secrets)
+	cr.Status.AtProvider.SinkSecretsHash = secrets.hash()
 
 	cr.SetConditions(xpv1.Creating(), xpv1.ReconcileSuccess())
 	logger.Info("Create completed successfully", "monitorID", created.ID)
 }
 
+// Create creates the RealtimeMonitor in Komodor within a span.
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	ctx, span := startSpan(ctx, "Create", mg)
+	cre, err := c.create(ctx, mg)
+	tracing.End(span, err)
+	return cre, err
+}
+
+func (c *external) create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	logger := log.FromContext(ctx)
 
-	cr, ok := mg.(*v1alpha1.RealtimeMonitor)
+	cr, ok := mg.(*v1beta1.RealtimeMonitor)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotRealtimeMonitor)
 	}
@@ -120,26 +135,20 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		"namespace", cr.Namespace,
 		"monitorName", cr.Spec.ForProvider.Name)
 
-	// Unmarshal spec data
-	specData, err := unmarshalSpecData(cr)
-	if err != nil {
-		return managed.ExternalCreation{}, err
-	}
-
 	// Validate clusters
-	if err := c.validateClusters(ctx, specData.sensors, cr, logger); err != nil {
+	if err := c.validateClusters(ctx, cr.Spec.ForProvider.Sensors, cr, logger); err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
 	// Create monitor in Komodor
 	logger.Info("Proceeding with monitor creation", "monitorName", cr.Spec.ForProvider.Name)
-	created, err := c.createMonitorInKomodor(ctx, specData, cr, logger)
+	created, secrets, err := c.createMonitorInKomodor(ctx, cr, logger)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
 	// Update resource with created monitor data
-	c.updateResourceFromCreatedMonitor(cr, created, logger)
+	c.updateResourceFromCreatedMonitor(cr, created, secrets, logger)
 
 	return managed.ExternalCreation{}, nil
 }
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package realtimemonitor
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+// monitorsForCredentials maps a Secret to the RealtimeMonitors whose
+// ProviderConfig reads its credentials or CA bundle from it, so they pick up
+// rotated credentials without waiting for the next poll.
+func monitorsForCredentials(kube client.Reader, log logging.Logger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		pcs, err := komodorclient.ProviderConfigsReferencingSecret(ctx, kube, o)
+		if err != nil {
+			log.Info("Cannot list ProviderConfigs reading credentials from Secret", "secret", o.GetNamespace()+"/"+o.GetName(), "error", err)
+			return nil
+		}
+		if len(pcs) == 0 {
+			return nil
+		}
+
+		names := map[string]bool{}
+		for _, pc := range pcs {
+			names[pc.GetName()] = true
+		}
+
+		l := &v1beta1.RealtimeMonitorList{}
+		if err := kube.List(ctx, l); err != nil {
+			log.Info("Cannot list RealtimeMonitors using rotated credentials", "secret", o.GetNamespace()+"/"+o.GetName(), "error", err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, m := range l.Items {
+			if ref := m.GetProviderConfigReference(); ref != nil && names[ref.Name] {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: m.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
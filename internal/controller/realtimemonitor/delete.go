@@ -18,6 +18,7 @@ package realtimemonitor
 
 import (
 	"context"
+	"strings"
 
 	meta "github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/pkg/errors"
@@ -26,13 +27,24 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
-	"github.com/crossplane/provider-komodor/apis/komodor/v1alpha1"
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+	"github.com/crossplane/provider-komodor/internal/tracing"
 )
 
+// Delete deletes or deactivates the RealtimeMonitor in Komodor within a
+// span.
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	ctx, span := startSpan(ctx, "Delete", mg)
+	del, err := c.delete(ctx, mg)
+	tracing.End(span, err)
+	return del, err
+}
+
+func (c *external) delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
 	logger := log.FromContext(ctx)
 
-	cr, ok := mg.(*v1alpha1.RealtimeMonitor)
+	cr, ok := mg.(*v1beta1.RealtimeMonitor)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotRealtimeMonitor)
 	}
@@ -47,9 +59,18 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, errors.New("external name (monitor ID) is not set")
 	}
 
+	if cr.Spec.DeletionMode == v1beta1.DeletionModeDeactivate {
+		return c.deactivateMonitor(ctx, cr, extName)
+	}
+
 	logger.Info("Sending delete request to Komodor", "monitorID", extName)
 
-	if err := c.client.DeleteMonitor(ctx, extName); err != nil {
+	err := c.client.DeleteMonitor(ctx, extName)
+	if komodorclient.IsNotFound(err) {
+		logger.Info("Monitor already deleted in Komodor", "monitorID", extName)
+		return managed.ExternalDelete{}, nil
+	}
+	if err != nil {
 		logger.Error(err, "Failed to delete monitor in Komodor", "monitorID", extName)
 		cr.SetConditions(xpv1.ReconcileError(errors.Wrap(err, "cannot delete monitor in Komodor")))
 		return managed.ExternalDelete{}, errors.Wrap(err, "cannot delete monitor in Komodor")
@@ -59,6 +80,46 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalDelete{}, nil
 }
 
+// Helper: Deactivate a monitor instead of deleting it, so Komodor keeps the
+// monitor and its history, and append the deactivated name suffix to its name
+func (c *external) deactivateMonitor(ctx context.Context, cr *v1beta1.RealtimeMonitor, monitorID string) (managed.ExternalDelete, error) {
+	logger := log.FromContext(ctx)
+
+	m, err := c.client.GetMonitor(ctx, monitorID)
+	if komodorclient.IsNotFound(err) {
+		logger.Info("Monitor already deleted in Komodor", "monitorID", monitorID)
+		return managed.ExternalDelete{}, nil
+	}
+	if err != nil {
+		cr.SetConditions(xpv1.ReconcileError(errors.Wrap(err, "cannot get monitor from Komodor")))
+		return managed.ExternalDelete{}, errors.Wrap(err, "cannot get monitor from Komodor")
+	}
+
+	// Send the monitor back as Komodor returned it, so deactivating it
+	// changes nothing but its state and name.
+	m.ID, m.CreatedAt, m.UpdatedAt, m.IsDeleted = "", "", "", false
+	m.Active = false
+	if !strings.HasSuffix(m.Name, cr.Spec.DeactivatedNameSuffix) {
+		m.Name += cr.Spec.DeactivatedNameSuffix
+	}
+
+	logger.Info("Deactivating monitor in Komodor instead of deleting it", "monitorID", monitorID, "monitorName", m.Name)
+	if _, err := c.client.UpdateMonitor(ctx, monitorID, m); err != nil {
+		cr.SetConditions(xpv1.ReconcileError(errors.Wrap(err, "cannot deactivate monitor in Komodor")))
+		return managed.ExternalDelete{}, errors.Wrap(err, "cannot deactivate monitor in Komodor")
+	}
+	return managed.ExternalDelete{AdditionalDetails: managed.AdditionalDetails{"deletionMode": string(v1beta1.DeletionModeDeactivate)}}, nil
+}
+
+// Helper: Whether a RealtimeMonitor being deleted in Deactivate mode has
+// deactivated its monitor, which then no longer counts as existing
+func isDeactivated(cr *v1beta1.RealtimeMonitor, m *komodorclient.Monitor) bool {
+	return meta.WasDeleted(cr) &&
+		cr.Spec.DeletionMode == v1beta1.DeletionModeDeactivate &&
+		!m.Active &&
+		strings.HasSuffix(m.Name, cr.Spec.DeactivatedNameSuffix)
+}
+
 func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
@@ -17,108 +17,167 @@ limitations under the License.
 package realtimemonitor
 
 import (
-	"encoding/json"
-	"reflect"
 	"regexp"
+	"slices"
 
-	"github.com/pkg/errors"
-	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
-	"github.com/crossplane/provider-komodor/apis/komodor/v1alpha1"
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
 	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
 )
 
-// Helper: Unmarshal apiextensionsv1.JSON slice to []map[string]interface{}
-func unmarshalSensors(jsons []apiextensionsv1.JSON) ([]map[string]interface{}, error) {
-	sensors := make([]map[string]interface{}, 0, len(jsons))
-	for _, s := range jsons {
-		var m map[string]interface{}
-		if err := json.Unmarshal(s.Raw, &m); err != nil {
-			return nil, err
+// Helper: Convert spec sensors to the Komodor model
+func sensorsToKomodor(in []v1beta1.Sensor) []komodorclient.Sensor {
+	if in == nil {
+		return nil
+	}
+	out := make([]komodorclient.Sensor, 0, len(in))
+	for _, s := range in {
+		sensor := komodorclient.Sensor{
+			Cluster:    s.Cluster,
+			Namespaces: s.Namespaces,
+			Services:   s.Services,
+			Conditions: s.Conditions,
+			Labels:     s.Labels,
+		}
+		if s.Exclude != nil {
+			sensor.Exclude = &komodorclient.SensorScope{Namespaces: s.Exclude.Namespaces, Services: s.Exclude.Services}
 		}
-		sensors = append(sensors, m)
+		out = append(out, sensor)
 	}
-	return sensors, nil
+	return out
 }
 
-// Helper: Unmarshal apiextensionsv1.JSON to map[string]interface{}
-func unmarshalMap(j apiextensionsv1.JSON) (map[string]interface{}, error) {
-	if j.Raw == nil {
-		return nil, nil
+// Helper: Convert Komodor sensors to their API form
+func sensorsFromKomodor(in []komodorclient.Sensor) []v1beta1.Sensor {
+	if in == nil {
+		return nil
 	}
-	var m map[string]interface{}
-	if err := json.Unmarshal(j.Raw, &m); err != nil {
-		return nil, err
+	out := make([]v1beta1.Sensor, 0, len(in))
+	for _, s := range in {
+		sensor := v1beta1.Sensor{
+			Cluster:    s.Cluster,
+			Namespaces: s.Namespaces,
+			Services:   s.Services,
+			Conditions: s.Conditions,
+			Labels:     s.Labels,
+		}
+		if s.Exclude != nil {
+			sensor.Exclude = &v1beta1.SensorScope{Namespaces: s.Exclude.Namespaces, Services: s.Exclude.Services}
+		}
+		out = append(out, sensor)
 	}
-	return m, nil
+	return out
 }
 
-// Helper: Marshal []map[string]interface{} to []apiextensionsv1.JSON
-func marshalSensors(sensors []map[string]interface{}) ([]apiextensionsv1.JSON, error) {
-	jsons := make([]apiextensionsv1.JSON, 0, len(sensors))
-	for _, s := range sensors {
-		b, err := json.Marshal(s)
-		if err != nil {
-			return nil, err
-		}
-		jsons = append(jsons, apiextensionsv1.JSON{Raw: b})
+// Helper: Convert Komodor sensors to their observation in the status
+func sensorObservations(in []komodorclient.Sensor) []v1beta1.SensorObservation {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1beta1.SensorObservation, 0, len(in))
+	for _, s := range sensorsFromKomodor(in) {
+		out = append(out, v1beta1.SensorObservation{
+			Cluster:    s.Cluster,
+			Namespaces: s.Namespaces,
+			Services:   s.Services,
+			Conditions: s.Conditions,
+			Labels:     s.Labels,
+			Exclude:    s.Exclude,
+		})
+	}
+	return out
+}
+
+// Helper: Convert spec sinks to the Komodor model
+func sinksToKomodor(in *v1beta1.Sinks) *komodorclient.Sinks {
+	if in == nil {
+		return nil
+	}
+	out := &komodorclient.Sinks{
+		Slack:          in.Slack,
+		Teams:          in.Teams,
+		Opsgenie:       in.Opsgenie,
+		GenericWebhook: in.GenericWebhook,
+	}
+	for _, pd := range in.PagerDuty {
+		out.PagerDuty = append(out.PagerDuty, komodorclient.PagerDutySink{
+			Channel:              pd.Channel,
+			IntegrationKey:       pd.IntegrationKey,
+			PagerDutyAccountName: pd.PagerDutyAccountName,
+		})
+	}
+	return out
+}
+
+// Helper: Convert Komodor sinks to their API form
+func sinksFromKomodor(in *komodorclient.Sinks) *v1beta1.Sinks {
+	if in == nil {
+		return nil
+	}
+	out := &v1beta1.Sinks{
+		Slack:          in.Slack,
+		Teams:          in.Teams,
+		Opsgenie:       in.Opsgenie,
+		GenericWebhook: in.GenericWebhook,
+	}
+	for _, pd := range in.PagerDuty {
+		out.PagerDuty = append(out.PagerDuty, v1beta1.PagerDutySink{
+			Channel:              pd.Channel,
+			IntegrationKey:       pd.IntegrationKey,
+			PagerDutyAccountName: pd.PagerDutyAccountName,
+		})
 	}
-	return jsons, nil
+	return out
 }
 
-// Helper: Marshal map[string]interface{} to apiextensionsv1.JSON
-func marshalMap(m map[string]interface{}) (apiextensionsv1.JSON, error) {
-	if m == nil {
-		return apiextensionsv1.JSON{}, nil
+// Helper: Convert spec variables to the Komodor model
+func variablesToKomodor(in *v1beta1.Variables) *komodorclient.Variables {
+	if in == nil {
+		return nil
 	}
-	b, err := json.Marshal(m)
-	if err != nil {
-		return apiextensionsv1.JSON{}, err
+	return &komodorclient.Variables{
+		Duration:              in.Duration,
+		MinAvailable:          in.MinAvailable,
+		Categories:            in.Categories,
+		Reasons:               in.Reasons,
+		NodeCreationThreshold: in.NodeCreationThreshold,
+		CronJobCondition:      in.CronJobCondition,
+		ResolveAfter:          in.ResolveAfter,
+		IgnoreAfter:           in.IgnoreAfter,
 	}
-	return apiextensionsv1.JSON{Raw: b}, nil
 }
 
-// Helper: Update status fields from a Monitor
-func updateStatusFromMonitor(cr *v1alpha1.RealtimeMonitor, m *komodorclient.Monitor) error {
+// Helper: Convert Komodor variables to their API form
+func variablesFromKomodor(in *komodorclient.Variables) *v1beta1.Variables {
+	if in == nil {
+		return nil
+	}
+	return &v1beta1.Variables{
+		Duration:              in.Duration,
+		MinAvailable:          in.MinAvailable,
+		Categories:            in.Categories,
+		Reasons:               in.Reasons,
+		NodeCreationThreshold: in.NodeCreationThreshold,
+		CronJobCondition:      in.CronJobCondition,
+		ResolveAfter:          in.ResolveAfter,
+		IgnoreAfter:           in.IgnoreAfter,
+	}
+}
+
+// Helper: Update status fields from a Monitor, leaving out sink secrets
+func updateStatusFromMonitor(cr *v1beta1.RealtimeMonitor, m *komodorclient.Monitor, secrets *sinkSecrets) {
 	cr.Status.AtProvider.ID = m.ID
 	cr.Status.AtProvider.Name = m.Name
 	cr.Status.AtProvider.Active = m.Active
 	cr.Status.AtProvider.Type = m.Type
-	jsons, err := marshalSensors(m.Sensors)
-	if err != nil {
-		return errors.Wrap(err, "failed to marshal sensors for status")
-	}
-	cr.Status.AtProvider.Sensors = jsons
-	if m.Sinks != nil {
-		j, err := marshalMap(m.Sinks)
-		if err != nil {
-			return errors.Wrap(err, "failed to marshal sinks for status")
-		}
-		cr.Status.AtProvider.Sinks = j
-	}
-	if m.Variables != nil {
-		j, err := marshalMap(m.Variables)
-		if err != nil {
-			return errors.Wrap(err, "failed to marshal variables for status")
-		}
-		cr.Status.AtProvider.Variables = j
-	}
+	cr.Status.AtProvider.Sensors = sensorObservations(m.Sensors)
+	cr.Status.AtProvider.Sinks = secrets.redact(sinksFromKomodor(m.Sinks))
+	cr.Status.AtProvider.Variables = variablesFromKomodor(m.Variables)
 	cr.Status.AtProvider.SinksOptions = m.SinksOptions
 	cr.Status.AtProvider.CreatedAt = m.CreatedAt
 	cr.Status.AtProvider.UpdatedAt = m.UpdatedAt
 	cr.Status.AtProvider.IsDeleted = m.IsDeleted
-	return nil
-}
-
-// Helper: Compare spec and monitor for up-to-date status
-func isMonitorUpToDate(spec *v1alpha1.RealtimeMonitorParameters, monitor *komodorclient.Monitor, specSensors []map[string]interface{}, specSinks, specVariables map[string]interface{}) bool {
-	return spec.Name == monitor.Name &&
-		reflect.DeepEqual(specSensors, monitor.Sensors) &&
-		reflect.DeepEqual(specSinks, monitor.Sinks) &&
-		spec.Active == monitor.Active &&
-		spec.Type == monitor.Type &&
-		reflect.DeepEqual(specVariables, monitor.Variables) &&
-		reflect.DeepEqual(spec.SinksOptions, monitor.SinksOptions)
 }
 
 // isValidUUID checks if a string is a valid UUID format
@@ -127,33 +186,51 @@ func isValidUUID(uuid string) bool {
 	return uuidRegex.MatchString(uuid)
 }
 
-// Helper struct for spec data
-type specData struct {
-	sensors   []map[string]interface{}
-	sinks     map[string]interface{}
-	variables map[string]interface{}
+// Helper: Build the Komodor monitor described by a spec, mapping the selected
+// monitor type onto the monitor's type and variables and adding the resolved
+// sink secrets
+func generateMonitor(spec *v1beta1.RealtimeMonitorParameters, secrets *sinkSecrets) *komodorclient.Monitor {
+	sinks := sinksToKomodor(&spec.Sinks)
+	secrets.apply(sinks)
+	return &komodorclient.Monitor{
+		Name:         spec.Name,
+		Sensors:      sensorsToKomodor(spec.Sensors),
+		Sinks:        sinks,
+		Active:       spec.Active,
+		Type:         spec.Type(),
+		Variables:    variablesToKomodor(spec.Variables()),
+		SinksOptions: spec.SinksOptions,
+	}
 }
 
-// Helper: Unmarshal spec data
-func unmarshalSpecData(cr *v1alpha1.RealtimeMonitor) (*specData, error) {
-	specSensors, err := unmarshalSensors(cr.Spec.ForProvider.Sensors)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal spec sensors")
+// Helper: Late initialize the forProvider fields a RealtimeMonitor leaves
+// unset from a monitor, unless its management policies exclude late
+// initialization. The desired parameters are filled alike; sink options they
+// take from notification channels are never copied into the spec.
+func lateInitialize(cr *v1beta1.RealtimeMonitor, desired *v1beta1.RealtimeMonitorParameters, m *komodorclient.Monitor) bool {
+	if !shouldLateInitialize(cr.GetManagementPolicies()) {
+		return false
 	}
-
-	specSinks, err := unmarshalMap(cr.Spec.ForProvider.Sinks)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal spec sinks")
+	observed := &v1beta1.RealtimeMonitorParameters{Sensors: sensorsFromKomodor(m.Sensors)}
+	if t, err := v1beta1.NewMonitorTypeParameters(m.Type, variablesFromKomodor(m.Variables)); err == nil {
+		observed.MonitorTypeParameters = t
 	}
-
-	specVariables, err := unmarshalMap(cr.Spec.ForProvider.Variables)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to unmarshal spec variables")
+	for k, v := range m.SinksOptions {
+		if _, ok := desired.SinksOptions[k]; ok {
+			continue
+		}
+		if observed.SinksOptions == nil {
+			observed.SinksOptions = map[string][]string{}
+		}
+		observed.SinksOptions[k] = v
 	}
+	desired.LateInitialize(observed)
+	return cr.Spec.ForProvider.LateInitialize(observed)
+}
 
-	return &specData{
-		sensors:   specSensors,
-		sinks:     specSinks,
-		variables: specVariables,
-	}, nil
+// Helper: Whether management policies allow late initialization
+func shouldLateInitialize(policies xpv1.ManagementPolicies) bool {
+	return len(policies) == 0 ||
+		slices.Contains(policies, xpv1.ManagementActionAll) ||
+		slices.Contains(policies, xpv1.ManagementActionLateInitialize)
 }
@@ -18,19 +18,23 @@ package realtimemonitor
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	meta "github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	"github.com/crossplane/provider-komodor/apis/komodor/v1alpha1"
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
 	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+	"github.com/crossplane/provider-komodor/internal/tracing"
 )
 
 // Helper: Fetch monitor from Komodor
@@ -53,7 +57,7 @@ func (c *external) fetchMonitorFromKomodor(ctx context.Context, monitorID string
 }
 
 // Helper: Set observe conditions
-func (c *external) setObserveConditions(cr *v1alpha1.RealtimeMonitor, resourceUpToDate bool, monitorID string, logger logr.Logger) {
+func (c *external) setObserveConditions(cr *v1beta1.RealtimeMonitor, resourceUpToDate bool, monitorID string, logger logr.Logger) {
 	if resourceUpToDate {
 		cr.SetConditions(xpv1.Available(), xpv1.ReconcileSuccess())
 		logger.Info("Monitor is up-to-date, set READY and SYNCED conditions to True", "monitorID", monitorID)
@@ -63,8 +67,20 @@ func (c *external) setObserveConditions(cr *v1alpha1.RealtimeMonitor, resourceUp
 	}
 }
 
+// Helper: Compare the desired parameters with a monitor, recording the
+// drifted fields in the status and, when they change, in an event
+func (c *external) observeDrift(cr *v1beta1.RealtimeMonitor, desired *v1beta1.RealtimeMonitorParameters, monitor *komodorclient.Monitor, secrets *sinkSecrets) []string {
+	drift := monitorDrift(desired, monitor, secrets, cr.Status.AtProvider.SinkSecretsHash)
+	if len(drift) > 0 && !slices.Equal(drift, cr.Status.AtProvider.DriftedFields) {
+		c.recorder.Event(cr, event.Normal(reasonDriftDetected,
+			fmt.Sprintf("Komodor monitor %s differs from the spec in %s", monitor.ID, strings.Join(drift, ", "))))
+	}
+	cr.Status.AtProvider.DriftedFields = drift
+	return drift
+}
+
 // Helper: Handle error from Komodor GetMonitor
-func handleGetMonitorError(ctx context.Context, cr *v1alpha1.RealtimeMonitor, extName string, err error) (managed.ExternalObservation, error) {
+func handleGetMonitorError(ctx context.Context, cr *v1beta1.RealtimeMonitor, extName string, err error) (managed.ExternalObservation, error) {
 	logger := log.FromContext(ctx)
 
 	// Check if this is a 404 Not Found error
@@ -75,7 +91,7 @@ func handleGetMonitorError(ctx context.Context, cr *v1alpha1.RealtimeMonitor, ex
 
 	// Check if this is a 400 Bad Request or 403 Forbidden with an invalid external name (not a UUID)
 	// This happens when Crossplane automatically sets external-name to the Kubernetes resource name
-	if strings.Contains(err.Error(), "400 Bad Request") || strings.Contains(err.Error(), "403 Forbidden") {
+	if komodorclient.IsBadRequest(err) || komodorclient.IsForbidden(err) {
 		if !isValidUUID(extName) {
 			logger.Info("400/403 error with invalid external name (not UUID), clearing external name to trigger creation",
 				"externalName", extName,
@@ -100,10 +116,19 @@ func handleGetMonitorError(ctx context.Context, cr *v1alpha1.RealtimeMonitor, ex
 	return managed.ExternalObservation{}, errors.Wrapf(err, "failed to get monitor %q from Komodor", extName)
 }
 
+// Observe observes the RealtimeMonitor in Komodor within a span, so the
+// Komodor requests it sends can be told apart in a trace.
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	ctx, span := startSpan(ctx, "Observe", mg)
+	obs, err := c.observe(ctx, mg)
+	tracing.End(span, err)
+	return obs, err
+}
+
+func (c *external) observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	logger := log.FromContext(ctx)
 
-	cr, ok := mg.(*v1alpha1.RealtimeMonitor)
+	cr, ok := mg.(*v1beta1.RealtimeMonitor)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotRealtimeMonitor)
 	}
@@ -116,6 +141,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	// Check if monitor exists
 	monitorID := meta.GetExternalName(cr)
 	if monitorID == "" {
+		if cr.Spec.AdoptionPolicy == v1beta1.AdoptionPolicyByName {
+			logger.Info("No external name found, looking for a monitor to adopt")
+			return c.adoptMonitor(ctx, cr)
+		}
 		logger.Info("No external name found, resource does not exist")
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
@@ -141,29 +170,47 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	// Unmarshal spec data
-	specData, err := unmarshalSpecData(cr)
+	// A monitor deactivated instead of deleted is gone as far as the
+	// deletion is concerned
+	if isDeactivated(cr, monitor) {
+		logger.Info("Monitor is deactivated, treating as non-existent", "monitorID", monitorID)
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// A monitor being deleted is not compared, so channels and secrets it
+	// references that are already gone do not block its deletion
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	desired, secrets, err := c.resolveDesired(ctx, cr)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
 
+	// Fill unset fields with Komodor's values before comparing, so they
+	// never count as drift
+	lateInitialized := lateInitialize(cr, desired, monitor)
+
 	// Check if monitor is up to date
-	resourceUpToDate := isMonitorUpToDate(&cr.Spec.ForProvider, monitor, specData.sensors, specData.sinks, specData.variables)
+	drift := c.observeDrift(cr, desired, monitor, secrets)
+	resourceUpToDate := len(drift) == 0
 	logger.Info("Monitor comparison completed",
 		"monitorID", monitorID,
-		"resourceUpToDate", resourceUpToDate)
+		"resourceUpToDate", resourceUpToDate,
+		"resourceLateInitialized", lateInitialized,
+		"driftedFields", drift)
 
 	// Update status from monitor
-	if err := updateStatusFromMonitor(cr, monitor); err != nil {
-		logger.Error(err, "Failed to update status from monitor")
-		return managed.ExternalObservation{}, err
-	}
+	updateStatusFromMonitor(cr, monitor, secrets)
 
 	// Set conditions based on resource state
 	c.setObserveConditions(cr, resourceUpToDate, monitorID, logger)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: resourceUpToDate,
+		ResourceExists:          true,
+		ResourceUpToDate:        resourceUpToDate,
+		ResourceLateInitialized: lateInitialized,
+		Diff:                    strings.Join(drift, ", "),
 	}, nil
 }
@@ -21,19 +21,26 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/statemetrics"
 
-	"github.com/crossplane/provider-komodor/apis/komodor/v1alpha1"
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
 	apisv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
 	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
 	"github.com/crossplane/provider-komodor/internal/features"
@@ -43,8 +50,9 @@ const (
 	errNotRealtimeMonitor = "managed resource is not a RealtimeMonitor custom resource"
 	errTrackPCUsage       = "cannot track ProviderConfig usage"
 	errGetPC              = "cannot get ProviderConfig"
-	errGetCreds           = "cannot get credentials"
 	errNewClient          = "cannot create new Service"
+
+	reasonDriftDetected event.Reason = "DriftDetected"
 )
 
 // Define KomodorClient interface for testability
@@ -56,34 +64,44 @@ type KomodorClient interface {
 	UpdateMonitor(ctx context.Context, id string, monitor *komodorclient.Monitor) (*komodorclient.Monitor, error)
 	DeleteMonitor(ctx context.Context, id string) error
 	ValidateCluster(ctx context.Context, clusterName string) (bool, error)
+	InvalidateClusters()
+}
+
+var tracer = otel.Tracer("github.com/crossplane/provider-komodor/internal/controller/realtimemonitor")
+
+// startSpan starts a span for the supplied operation on a RealtimeMonitor.
+func startSpan(ctx context.Context, op string, mg resource.Managed) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "RealtimeMonitor."+op, trace.WithAttributes(
+		attribute.String("crossplane.resource.name", mg.GetName()),
+		attribute.String("komodor.monitor.id", meta.GetExternalName(mg)),
+	))
 }
 
 // A NoOpService does nothing.
 type NoOpService struct{}
 
-var (
-	newKomodorClient = func(apiKey []byte) (interface{}, error) {
-		return komodorclient.NewClient(string(apiKey)), nil
-	}
-)
-
 // Setup adds a controller that reconciles RealtimeMonitor managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
-	name := managed.ControllerName(v1alpha1.RealtimeMonitorGroupKind)
+	name := managed.ControllerName(v1beta1.RealtimeMonitorGroupKind)
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
 	}
 
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	clients := komodorclient.NewClientCache()
+
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnecter(&connector{
-			kube:         mgr.GetClient(),
-			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: newKomodorClient}),
+			kube:      mgr.GetClient(),
+			recorder:  recorder,
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			inventory: komodorclient.NewClusterInventory(),
+			clients:   clients}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...),
 		managed.WithManagementPolicies(),
 	}
@@ -98,38 +116,58 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 	if o.MetricOptions != nil && o.MetricOptions.MRStateMetrics != nil {
 		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
-			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1alpha1.RealtimeMonitorList{}, o.MetricOptions.PollStateMetricInterval,
+			mgr.GetClient(), o.Logger, o.MetricOptions.MRStateMetrics, &v1beta1.RealtimeMonitorList{}, o.MetricOptions.PollStateMetricInterval,
 		)
 		if err := mgr.Add(stateMetricsRecorder); err != nil {
-			return errors.Wrap(err, "cannot register MR state metrics recorder for kind v1alpha1.RealtimeMonitorList")
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind v1beta1.RealtimeMonitorList")
 		}
 	}
 
-	r := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.RealtimeMonitorGroupVersionKind), opts...)
+	r := managed.NewReconciler(mgr, resource.ManagedKind(v1beta1.RealtimeMonitorGroupVersionKind), opts...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
-		WithEventFilter(resource.DesiredStateChanged()).
-		For(&v1alpha1.RealtimeMonitor{}).
+		For(&v1beta1.RealtimeMonitor{}, builder.WithPredicates(resource.DesiredStateChanged())).
+		Watches(&v1beta1.NotificationChannel{}, handler.EnqueueRequestsFromMapFunc(monitorsForChannel(mgr.GetClient(), o.Logger)),
+			builder.WithPredicates(resource.DesiredStateChanged())).
+		// Secrets have no generation, so their watches must not filter on
+		// desired state changes.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(monitorsForCredentials(mgr.GetClient(), o.Logger))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(monitorsForSinkSecret(mgr.GetClient(), o.Logger))).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
+// SetupWebhook adds the webhook that converts RealtimeMonitors between API
+// versions.
+func SetupWebhook(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1beta1.RealtimeMonitor{}).
+		Complete()
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube         client.Client
-	usage        resource.Tracker
-	newServiceFn func(creds []byte) (interface{}, error)
+	kube     client.Client
+	usage    resource.Tracker
+	recorder event.Recorder
+
+	// inventory caches the Komodor cluster inventory across all clients
+	// this connector creates.
+	inventory *komodorclient.ClusterInventory
+
+	// clients reuses Komodor clients across reconciles.
+	clients *komodorclient.ClientCache
 }
 
 // Connect typically produces an ExternalClient by:
 // 1. Tracking that the managed resource is using a ProviderConfig.
 // 2. Getting the managed resource's ProviderConfig.
 // 3. Getting the credentials specified by the ProviderConfig.
-// 4. Using the credentials to form a client.
+// 4. Using the credentials and endpoint to form a client.
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.RealtimeMonitor)
+	cr, ok := mg.(*v1beta1.RealtimeMonitor)
 	if !ok {
 		return nil, errors.New(errNotRealtimeMonitor)
 	}
@@ -143,26 +181,20 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
-	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-
-	svc, err := c.newServiceFn(data)
+	client, err := komodorclient.ProviderConfigClient(ctx, c.kube, pc, c.clients, komodorclient.WithClusterInventory(c.inventory))
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-
-	client, ok := svc.(*komodorclient.Client)
-	if !ok {
-		return nil, errors.New("failed to cast to Komodor client")
-	}
-
-	return &external{client: client}, nil
+	return &external{client: client, kube: c.kube, recorder: c.recorder}, nil
 }
 
 // external implements managed.ExternalClient using the Komodor client.
 type external struct {
 	client KomodorClient
+
+	// kube reads the Secrets sink secret references select.
+	kube client.Client
+
+	// recorder reports drift from the Komodor monitor.
+	recorder event.Recorder
 }
@@ -20,17 +20,32 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
-	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
-	"github.com/crossplane/provider-komodor/apis/komodor/v1alpha1"
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	apisv1alpha1 "github.com/crossplane/provider-komodor/apis/v1alpha1"
 	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
 )
 
@@ -44,7 +59,10 @@ import (
 
 // Mock Komodor client
 type mockClient struct {
-	getMonitorFn func(ctx context.Context, id string) (*komodorclient.Monitor, error)
+	getMonitorFn    func(ctx context.Context, id string) (*komodorclient.Monitor, error)
+	listMonitorsFn  func(ctx context.Context) ([]komodorclient.Monitor, error)
+	updateMonitorFn func(ctx context.Context, id string, monitor *komodorclient.Monitor) (*komodorclient.Monitor, error)
+	deleteMonitorFn func(ctx context.Context, id string) error
 }
 
 func (m *mockClient) GetMonitor(ctx context.Context, id string) (*komodorclient.Monitor, error) {
@@ -52,6 +70,9 @@ func (m *mockClient) GetMonitor(ctx context.Context, id string) (*komodorclient.
 }
 
 func (m *mockClient) ListMonitors(ctx context.Context) ([]komodorclient.Monitor, error) {
+	if m.listMonitorsFn != nil {
+		return m.listMonitorsFn(ctx)
+	}
 	return nil, nil
 }
 
@@ -66,10 +87,16 @@ func (m *mockClient) CreateMonitor(ctx context.Context, monitor *komodorclient.M
 }
 
 func (m *mockClient) UpdateMonitor(ctx context.Context, id string, monitor *komodorclient.Monitor) (*komodorclient.Monitor, error) {
+	if m.updateMonitorFn != nil {
+		return m.updateMonitorFn(ctx, id, monitor)
+	}
 	return nil, nil
 }
 
 func (m *mockClient) DeleteMonitor(ctx context.Context, id string) error {
+	if m.deleteMonitorFn != nil {
+		return m.deleteMonitorFn(ctx, id)
+	}
 	return nil
 }
 
@@ -77,6 +104,21 @@ func (m *mockClient) ValidateCluster(ctx context.Context, clusterName string) (b
 	return true, nil
 }
 
+func (m *mockClient) InvalidateClusters() {}
+
+// Event recorder that keeps the events it records
+type mockRecorder struct {
+	events []event.Event
+}
+
+func (r *mockRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *mockRecorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
 func TestObserve(t *testing.T) {
 	type fields struct {
 		client *mockClient
@@ -92,15 +134,6 @@ func TestObserve(t *testing.T) {
 		err error
 	}
 
-	// Helper to marshal to apiextensionsv1.JSON
-	marshalJSON := func(v interface{}) v1.JSON {
-		b, err := json.Marshal(v)
-		if err != nil {
-			t.Fatalf("failed to marshal: %v", err)
-		}
-		return v1.JSON{Raw: b}
-	}
-
 	// In test cases, provide the expected monitor for each scenario
 	cases := map[string]struct {
 		reason string
@@ -113,7 +146,7 @@ func TestObserve(t *testing.T) {
 			fields: fields{client: &mockClient{getMonitorFn: func(ctx context.Context, id string) (*komodorclient.Monitor, error) { return nil, nil }}},
 			args: args{
 				ctx: context.TODO(),
-				mg:  &v1alpha1.RealtimeMonitor{},
+				mg:  &v1beta1.RealtimeMonitor{},
 			},
 			want: want{
 				o:   managed.ExternalObservation{ResourceExists: false},
@@ -121,37 +154,38 @@ func TestObserve(t *testing.T) {
 			},
 		},
 		"ResourceUpToDate": {
-			reason: "If all fields match, resource is up to date.",
+			reason: "If all modelled fields match, resource is up to date even if Komodor returns unknown fields or sensors use cluster references.",
 			fields: fields{client: &mockClient{getMonitorFn: func(ctx context.Context, id string) (*komodorclient.Monitor, error) {
 				return &komodorclient.Monitor{
 					ID:           "12345678-1234-1234-1234-123456789abc",
 					Name:         "foo",
-					Sensors:      []map[string]interface{}{{"a": float64(1)}},
-					Sinks:        map[string]interface{}{"b": float64(2)},
+					Sensors:      []komodorclient.Sensor{{Cluster: "prod", Extra: komodorclient.Extra{"a": json.RawMessage("1")}}},
+					Sinks:        &komodorclient.Sinks{Slack: []string{"alerts"}},
 					Active:       true,
-					Type:         "bar",
-					Variables:    map[string]interface{}{"c": float64(3)},
+					Type:         "availability",
+					Variables:    &komodorclient.Variables{Duration: ptr.To(30)},
 					SinksOptions: map[string][]string{"notifyOn": {"x"}},
 				}, nil
 			}}},
 			args: args{
 				ctx: context.TODO(),
-				mg: &v1alpha1.RealtimeMonitor{
+				mg: &v1beta1.RealtimeMonitor{
 					ObjectMeta: metav1.ObjectMeta{
 						Annotations: map[string]string{"crossplane.io/external-name": "12345678-1234-1234-1234-123456789abc"},
 					},
-					Spec: v1alpha1.RealtimeMonitorSpec{
-						ForProvider: v1alpha1.RealtimeMonitorParameters{
-							Name:         "foo",
-							Sensors:      []v1.JSON{marshalJSON(map[string]interface{}{"a": 1})},
-							Sinks:        marshalJSON(map[string]interface{}{"b": 2}),
-							Active:       true,
-							Type:         "bar",
-							Variables:    marshalJSON(map[string]interface{}{"c": 3}),
+					Spec: v1beta1.RealtimeMonitorSpec{
+						ForProvider: v1beta1.RealtimeMonitorParameters{
+							Name:    "foo",
+							Sensors: []v1beta1.Sensor{{Cluster: "prod", ClusterRef: &xpv1.Reference{Name: "prod"}}},
+							Sinks:   v1beta1.Sinks{Slack: []string{"alerts"}},
+							Active:  true,
+							MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+								Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30)},
+							},
 							SinksOptions: map[string][]string{"notifyOn": {"x"}},
 						},
 					},
-					Status: v1alpha1.RealtimeMonitorStatus{},
+					Status: v1beta1.RealtimeMonitorStatus{},
 				},
 			},
 			want: want{
@@ -168,38 +202,114 @@ func TestObserve(t *testing.T) {
 				return &komodorclient.Monitor{
 					ID:           "12345678-1234-1234-1234-123456789abc",
 					Name:         "foo",
-					Sensors:      []map[string]interface{}{{"a": float64(2)}}, // different value
-					Sinks:        map[string]interface{}{"b": float64(2)},
+					Sensors:      []komodorclient.Sensor{{Cluster: "staging"}}, // different value
+					Sinks:        &komodorclient.Sinks{Slack: []string{"alerts"}},
+					Active:       true,
+					Type:         "availability",
+					Variables:    &komodorclient.Variables{Duration: ptr.To(30)},
+					SinksOptions: map[string][]string{"notifyOn": {"x"}},
+				}, nil
+			}}},
+			args: args{
+				ctx: context.TODO(),
+				mg: &v1beta1.RealtimeMonitor{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{"crossplane.io/external-name": "12345678-1234-1234-1234-123456789abc"},
+					},
+					Spec: v1beta1.RealtimeMonitorSpec{
+						ForProvider: v1beta1.RealtimeMonitorParameters{
+							Name:    "foo",
+							Sensors: []v1beta1.Sensor{{Cluster: "prod"}},
+							Sinks:   v1beta1.Sinks{Slack: []string{"alerts"}},
+							Active:  true,
+							MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+								Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30)},
+							},
+							SinksOptions: map[string][]string{"notifyOn": {"x"}},
+						},
+					},
+					Status: v1beta1.RealtimeMonitorStatus{},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					Diff:             "sensors[prod], sensors[staging]",
+				},
+				err: nil,
+			},
+		},
+		"DeactivatedWhileDeleting": {
+			reason: "A monitor deactivated by a RealtimeMonitor being deleted in Deactivate mode should not exist.",
+			fields: fields{client: &mockClient{getMonitorFn: func(ctx context.Context, id string) (*komodorclient.Monitor, error) {
+				return &komodorclient.Monitor{
+					ID:     "12345678-1234-1234-1234-123456789abc",
+					Name:   "foo (orphaned)",
+					Active: false,
+					Type:   "availability",
+				}, nil
+			}}},
+			args: args{
+				ctx: context.TODO(),
+				mg: &v1beta1.RealtimeMonitor{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations:       map[string]string{"crossplane.io/external-name": "12345678-1234-1234-1234-123456789abc"},
+						DeletionTimestamp: &metav1.Time{Time: time.Now()},
+					},
+					Spec: v1beta1.RealtimeMonitorSpec{
+						DeletionMode:          v1beta1.DeletionModeDeactivate,
+						DeactivatedNameSuffix: " (orphaned)",
+						ForProvider: v1beta1.RealtimeMonitorParameters{
+							Name: "foo",
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"MonitorTypeChanged": {
+			reason: "If the monitor type in Komodor differs from the selected type, resource is not up to date.",
+			fields: fields{client: &mockClient{getMonitorFn: func(ctx context.Context, id string) (*komodorclient.Monitor, error) {
+				return &komodorclient.Monitor{
+					ID:           "12345678-1234-1234-1234-123456789abc",
+					Name:         "foo",
+					Sensors:      []komodorclient.Sensor{{Cluster: "prod"}},
+					Sinks:        &komodorclient.Sinks{Slack: []string{"alerts"}},
 					Active:       true,
-					Type:         "bar",
-					Variables:    map[string]interface{}{"c": float64(3)},
+					Type:         "node", // different type
+					Variables:    &komodorclient.Variables{Duration: ptr.To(30)},
 					SinksOptions: map[string][]string{"notifyOn": {"x"}},
 				}, nil
 			}}},
 			args: args{
 				ctx: context.TODO(),
-				mg: &v1alpha1.RealtimeMonitor{
+				mg: &v1beta1.RealtimeMonitor{
 					ObjectMeta: metav1.ObjectMeta{
 						Annotations: map[string]string{"crossplane.io/external-name": "12345678-1234-1234-1234-123456789abc"},
 					},
-					Spec: v1alpha1.RealtimeMonitorSpec{
-						ForProvider: v1alpha1.RealtimeMonitorParameters{
-							Name:         "foo",
-							Sensors:      []v1.JSON{marshalJSON(map[string]interface{}{"a": 1})},
-							Sinks:        marshalJSON(map[string]interface{}{"b": 2}),
-							Active:       true,
-							Type:         "bar",
-							Variables:    marshalJSON(map[string]interface{}{"c": 3}),
+					Spec: v1beta1.RealtimeMonitorSpec{
+						ForProvider: v1beta1.RealtimeMonitorParameters{
+							Name:    "foo",
+							Sensors: []v1beta1.Sensor{{Cluster: "prod"}},
+							Sinks:   v1beta1.Sinks{Slack: []string{"alerts"}},
+							Active:  true,
+							MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+								Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30)},
+							},
 							SinksOptions: map[string][]string{"notifyOn": {"x"}},
 						},
 					},
-					Status: v1alpha1.RealtimeMonitorStatus{},
+					Status: v1beta1.RealtimeMonitorStatus{},
 				},
 			},
 			want: want{
 				o: managed.ExternalObservation{
 					ResourceExists:   true,
 					ResourceUpToDate: false,
+					Diff:             "type",
 				},
 				err: nil,
 			},
@@ -208,7 +318,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client}
+			e := external{client: tc.fields.client, recorder: event.NewNopRecorder()}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -219,3 +329,883 @@ func TestObserve(t *testing.T) {
 		})
 	}
 }
+
+func TestObserveAdoption(t *testing.T) {
+	spec := func(policy v1beta1.AdoptionPolicy) *v1beta1.RealtimeMonitor {
+		return &v1beta1.RealtimeMonitor{
+			Spec: v1beta1.RealtimeMonitorSpec{
+				AdoptionPolicy: policy,
+				ForProvider: v1beta1.RealtimeMonitorParameters{
+					Name:    "foo",
+					Sensors: []v1beta1.Sensor{{Cluster: "prod"}},
+					Sinks:   v1beta1.Sinks{Slack: []string{"alerts"}},
+					Active:  true,
+					MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+						Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30)},
+					},
+				},
+			},
+		}
+	}
+	monitor := func(id, name, monitorType string) komodorclient.Monitor {
+		return komodorclient.Monitor{
+			ID:        id,
+			Name:      name,
+			Sensors:   []komodorclient.Sensor{{Cluster: "prod"}},
+			Sinks:     &komodorclient.Sinks{Slack: []string{"alerts"}},
+			Active:    true,
+			Type:      monitorType,
+			Variables: &komodorclient.Variables{Duration: ptr.To(30)},
+		}
+	}
+
+	type want struct {
+		o            managed.ExternalObservation
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1beta1.RealtimeMonitor
+		monitors []komodorclient.Monitor
+		want     want
+	}{
+		"NeverAdopts": {
+			reason:   "Without an adoption policy an existing monitor of the same name should not be adopted.",
+			cr:       spec(""),
+			monitors: []komodorclient.Monitor{monitor("12345678-1234-1234-1234-123456789abc", "foo", "availability")},
+			want:     want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"AdoptsOnlyMatch": {
+			reason: "The only monitor with the same name and type should be adopted and its ID persisted.",
+			cr:     spec(v1beta1.AdoptionPolicyByName),
+			monitors: []komodorclient.Monitor{
+				monitor("12345678-1234-1234-1234-123456789abc", "foo", "availability"),
+				monitor("22345678-1234-1234-1234-123456789abc", "foo", "node"),
+				monitor("32345678-1234-1234-1234-123456789abc", "bar", "availability"),
+			},
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				externalName: "12345678-1234-1234-1234-123456789abc",
+			},
+		},
+		"NoMatch": {
+			reason:   "If no monitor matches, the resource should be created.",
+			cr:       spec(v1beta1.AdoptionPolicyByName),
+			monitors: []komodorclient.Monitor{monitor("32345678-1234-1234-1234-123456789abc", "bar", "availability")},
+			want:     want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"SkipsDeleted": {
+			reason: "Deleted monitors should not be adopted.",
+			cr:     spec(v1beta1.AdoptionPolicyByName),
+			monitors: func() []komodorclient.Monitor {
+				m := monitor("12345678-1234-1234-1234-123456789abc", "foo", "availability")
+				m.IsDeleted = true
+				return []komodorclient.Monitor{m}
+			}(),
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"RefusesAmbiguousMatch": {
+			reason: "If several monitors match, adoption should be refused.",
+			cr:     spec(v1beta1.AdoptionPolicyByName),
+			monitors: []komodorclient.Monitor{
+				monitor("12345678-1234-1234-1234-123456789abc", "foo", "availability"),
+				monitor("22345678-1234-1234-1234-123456789abc", "foo", "availability"),
+			},
+			want: want{
+				err: errors.New(`cannot adopt monitor: 2 Komodor monitors named "foo" of type "availability" exist (12345678-1234-1234-1234-123456789abc, 22345678-1234-1234-1234-123456789abc), set the crossplane.io/external-name annotation to the ID of the one to manage`),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: &mockClient{listMonitorsFn: func(context.Context) ([]komodorclient.Monitor, error) {
+				return tc.monitors, nil
+			}}, recorder: event.NewNopRecorder()}
+			got, err := e.Observe(context.TODO(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.cr)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveSinkSecrets(t *testing.T) {
+	const id = "12345678-1234-1234-1234-123456789abc"
+	errBoom := errors.New("boom")
+
+	monitor := &komodorclient.Monitor{
+		ID:      id,
+		Name:    "foo",
+		Sensors: []komodorclient.Sensor{{Cluster: "prod"}},
+		Sinks: &komodorclient.Sinks{
+			GenericWebhook: []string{"ops", "https://hooks.example.com/s3cr3t"},
+			PagerDuty:      []komodorclient.PagerDutySink{{Channel: "oncall", IntegrationKey: "r0uting"}},
+		},
+		Active:    true,
+		Type:      "availability",
+		Variables: &komodorclient.Variables{Duration: ptr.To(30)},
+	}
+	secrets := &sinkSecrets{genericWebhook: []string{"https://hooks.example.com/s3cr3t"}, pagerDuty: map[int]string{0: "r0uting"}, key: []byte("monitor-uid")}
+	otherMonitor := &sinkSecrets{genericWebhook: secrets.genericWebhook, pagerDuty: secrets.pagerDuty, key: []byte("other-uid")}
+
+	cr := func(appliedHash string) *v1beta1.RealtimeMonitor {
+		return &v1beta1.RealtimeMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:         "monitor-uid",
+				Annotations: map[string]string{"crossplane.io/external-name": id},
+			},
+			Spec: v1beta1.RealtimeMonitorSpec{
+				ForProvider: v1beta1.RealtimeMonitorParameters{
+					Name:    "foo",
+					Sensors: []v1beta1.Sensor{{Cluster: "prod"}},
+					Sinks: v1beta1.Sinks{
+						GenericWebhook:           []string{"ops"},
+						GenericWebhookSecretRefs: []xpv1.SecretKeySelector{{SecretReference: xpv1.SecretReference{Namespace: "ns", Name: "sinks"}, Key: "webhook"}},
+						PagerDuty: []v1beta1.PagerDutySink{{
+							Channel:                 "oncall",
+							IntegrationKeySecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "ns", Name: "sinks"}, Key: "pagerduty"},
+						}},
+					},
+					Active: true,
+					MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+						Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30)},
+					},
+				},
+			},
+			Status: v1beta1.RealtimeMonitorStatus{
+				AtProvider: v1beta1.RealtimeMonitorObservation{SinkSecretsHash: appliedHash},
+			},
+		}
+	}
+
+	getSecret := func(data map[string][]byte) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = data
+			return nil
+		}
+	}
+
+	type want struct {
+		o     managed.ExternalObservation
+		sinks *v1beta1.Sinks
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		mg     *v1beta1.RealtimeMonitor
+		want   want
+	}{
+		"SecretsApplied": {
+			reason: "Secret sink values Komodor has should not count as drift and should not be mirrored into the status.",
+			get:    getSecret(map[string][]byte{"webhook": []byte("https://hooks.example.com/s3cr3t"), "pagerduty": []byte("r0uting")}),
+			mg:     cr(secrets.hash()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				sinks: &v1beta1.Sinks{
+					GenericWebhook: []string{"ops"},
+					PagerDuty:      []v1beta1.PagerDutySink{{Channel: "oncall"}},
+				},
+			},
+		},
+		"SecretRotated": {
+			reason: "A secret whose hash differs from the one last sent to Komodor should make the resource not up to date.",
+			get:    getSecret(map[string][]byte{"webhook": []byte("https://hooks.example.com/n3w"), "pagerduty": []byte("r0uting")}),
+			mg:     cr(secrets.hash()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "sinks.genericWebhook, sinks.secrets"},
+				sinks: &v1beta1.Sinks{
+					GenericWebhook: []string{"ops", "https://hooks.example.com/s3cr3t"},
+					PagerDuty:      []v1beta1.PagerDutySink{{Channel: "oncall"}},
+				},
+			},
+		},
+		"HashOfOtherMonitor": {
+			reason: "The hash of the same secrets keyed by another monitor's UID should not match.",
+			get:    getSecret(map[string][]byte{"webhook": []byte("https://hooks.example.com/s3cr3t"), "pagerduty": []byte("r0uting")}),
+			mg:     cr(otherMonitor.hash()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "sinks.secrets"},
+				sinks: &v1beta1.Sinks{
+					GenericWebhook: []string{"ops"},
+					PagerDuty:      []v1beta1.PagerDutySink{{Channel: "oncall"}},
+				},
+			},
+		},
+		"MissingKey": {
+			reason: "A secret reference to a missing key should return an error.",
+			get:    getSecret(map[string][]byte{"pagerduty": []byte("r0uting")}),
+			mg:     cr(""),
+			want: want{
+				err: errors.Wrap(errors.New(`secret ns/sinks has no key "webhook"`), "cannot resolve genericWebhook sink secrets"),
+			},
+		},
+		"GetSecretError": {
+			reason: "Errors reading a secret should be returned.",
+			get:    test.NewMockGetFn(errBoom),
+			mg:     cr(""),
+			want: want{
+				err: errors.Wrap(errors.Wrap(errBoom, "cannot get secret ns/sinks"), "cannot resolve genericWebhook sink secrets"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client:   &mockClient{getMonitorFn: func(context.Context, string) (*komodorclient.Monitor, error) { return monitor, nil }},
+				kube:     &test.MockClient{MockGet: tc.get},
+				recorder: event.NewNopRecorder(),
+			}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.sinks, tc.mg.Status.AtProvider.Sinks); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status sinks, +got status sinks:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveNotificationChannels(t *testing.T) {
+	const id = "12345678-1234-1234-1234-123456789abc"
+	errBoom := errors.New("boom")
+
+	monitor := &komodorclient.Monitor{
+		ID:           id,
+		Name:         "foo",
+		Sensors:      []komodorclient.Sensor{{Cluster: "prod"}},
+		Sinks:        &komodorclient.Sinks{Slack: []string{"team", "oncall"}, Teams: []string{"ops"}},
+		Active:       true,
+		Type:         "availability",
+		Variables:    &komodorclient.Variables{Duration: ptr.To(30)},
+		SinksOptions: map[string][]string{"notifyOn": {"OOMKilled", "BackOff"}},
+	}
+
+	cr := &v1beta1.RealtimeMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"crossplane.io/external-name": id},
+		},
+		Spec: v1beta1.RealtimeMonitorSpec{
+			ForProvider: v1beta1.RealtimeMonitorParameters{
+				Name:                 "foo",
+				Sensors:              []v1beta1.Sensor{{Cluster: "prod"}},
+				Sinks:                v1beta1.Sinks{Slack: []string{"team"}},
+				NotificationChannels: []string{"oncall"},
+				Active:               true,
+				MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+					Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30)},
+				},
+				SinksOptions: map[string][]string{"notifyOn": {"OOMKilled"}},
+			},
+		},
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		get      test.MockGetFn
+		deleting bool
+		want     want
+	}{
+		"ChannelMerged": {
+			reason: "Sinks and sink options of referenced channels should be added to the monitor's own before comparing.",
+			get: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*v1beta1.NotificationChannel).Spec.ForProvider = v1beta1.NotificationChannelParameters{
+					Sinks:        v1beta1.Sinks{Slack: []string{"team", "oncall"}, Teams: []string{"ops"}},
+					SinksOptions: map[string][]string{"notifyOn": {"OOMKilled", "BackOff"}},
+				}
+				return nil
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ChannelChanged": {
+			reason: "A monitor should not be up to date once a referenced channel's sinks change.",
+			get: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*v1beta1.NotificationChannel).Spec.ForProvider = v1beta1.NotificationChannelParameters{
+					Sinks: v1beta1.Sinks{Slack: []string{"oncall-new"}},
+				}
+				return nil
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "sinks.slack, sinks.teams, sinksOptions.notifyOn"},
+			},
+		},
+		"GetChannelError": {
+			reason: "Errors getting a referenced channel should be returned.",
+			get:    test.NewMockGetFn(errBoom),
+			want: want{
+				err: errors.Wrap(errBoom, `cannot get NotificationChannel "oncall"`),
+			},
+		},
+		"ChannelNotFound": {
+			reason: "A referenced channel that does not exist should be reported by name.",
+			get:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "oncall")),
+			want: want{
+				err: errors.Errorf(errFmtChannelNotFound, "oncall"),
+			},
+		},
+		"ChannelNotFoundWhileDeleting": {
+			reason:   "A referenced channel that no longer exists should not block the deletion of the monitor.",
+			get:      test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "oncall")),
+			deleting: true,
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client:   &mockClient{getMonitorFn: func(context.Context, string) (*komodorclient.Monitor, error) { return monitor, nil }},
+				kube:     &test.MockClient{MockGet: tc.get},
+				recorder: event.NewNopRecorder(),
+			}
+			mg := cr.DeepCopy()
+			if tc.deleting {
+				mg.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			}
+			got, err := e.Observe(context.Background(), mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveDrift(t *testing.T) {
+	monitor := &komodorclient.Monitor{
+		ID:        "12345678-1234-1234-1234-123456789abc",
+		Name:      "foo",
+		Active:    true,
+		Type:      "availability",
+		Sensors:   []komodorclient.Sensor{{Cluster: "prod"}},
+		Sinks:     &komodorclient.Sinks{Slack: []string{"alerts"}},
+		Variables: &komodorclient.Variables{Duration: ptr.To(30)},
+	}
+	cr := func(duration int, drifted ...string) *v1beta1.RealtimeMonitor {
+		return &v1beta1.RealtimeMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{meta.AnnotationKeyExternalName: monitor.ID},
+			},
+			Spec: v1beta1.RealtimeMonitorSpec{
+				ForProvider: v1beta1.RealtimeMonitorParameters{
+					Name:    "foo",
+					Active:  true,
+					Sensors: []v1beta1.Sensor{{Cluster: "prod"}},
+					Sinks:   v1beta1.Sinks{Slack: []string{"alerts"}},
+					MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+						Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(duration)},
+					},
+				},
+			},
+			Status: v1beta1.RealtimeMonitorStatus{
+				AtProvider: v1beta1.RealtimeMonitorObservation{DriftedFields: drifted},
+			},
+		}
+	}
+
+	type want struct {
+		o       managed.ExternalObservation
+		drifted []string
+		events  []event.Event
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.RealtimeMonitor
+		want   want
+	}{
+		"NoDrift": {
+			reason: "A monitor that matches the spec should clear the drifted fields without an event.",
+			mg:     cr(30, "availability.duration"),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DriftDetected": {
+			reason: "Newly drifted fields should be reported in the status, the observation and an event.",
+			mg:     cr(45),
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "availability.duration"},
+				drifted: []string{"availability.duration"},
+				events: []event.Event{event.Normal(reasonDriftDetected,
+					"Komodor monitor 12345678-1234-1234-1234-123456789abc differs from the spec in availability.duration")},
+			},
+		},
+		"DriftUnchanged": {
+			reason: "Drift that was already reported should not be reported in another event.",
+			mg:     cr(45, "availability.duration"),
+			want: want{
+				o:       managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, Diff: "availability.duration"},
+				drifted: []string{"availability.duration"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &mockRecorder{}
+			e := external{
+				client:   &mockClient{getMonitorFn: func(context.Context, string) (*komodorclient.Monitor, error) { return monitor, nil }},
+				recorder: r,
+			}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.drifted, tc.mg.Status.AtProvider.DriftedFields); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want driftedFields, +got driftedFields:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, r.events); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cr := func(drifted ...string) *v1beta1.RealtimeMonitor {
+		return &v1beta1.RealtimeMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{meta.AnnotationKeyExternalName: "12345678-1234-1234-1234-123456789abc"},
+			},
+			Spec: v1beta1.RealtimeMonitorSpec{
+				ForProvider: v1beta1.RealtimeMonitorParameters{
+					Name:    "foo",
+					Sensors: []v1beta1.Sensor{{Cluster: "prod"}},
+					Sinks:   v1beta1.Sinks{Slack: []string{"alerts"}},
+					MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+						Deploy: &v1beta1.DeployMonitor{},
+					},
+				},
+			},
+			Status: v1beta1.RealtimeMonitorStatus{
+				AtProvider: v1beta1.RealtimeMonitorObservation{DriftedFields: drifted},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.RealtimeMonitor
+		want   managed.ExternalUpdate
+	}{
+		"DriftedFields": {
+			reason: "The change log of an update should record the drifted fields it corrected.",
+			mg:     cr("active", "sensors[prod]"),
+			want: managed.ExternalUpdate{
+				AdditionalDetails: managed.AdditionalDetails{"driftedFields": "active, sensors[prod]"},
+			},
+		},
+		"NoDriftedFields": {
+			reason: "An update without drifted fields should not add change log details.",
+			mg:     cr(),
+			want:   managed.ExternalUpdate{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: &mockClient{updateMonitorFn: func(_ context.Context, id string, m *komodorclient.Monitor) (*komodorclient.Monitor, error) {
+				m.ID = id
+				return m, nil
+			}}}
+			got, err := e.Update(context.Background(), tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Update(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveLateInitialization(t *testing.T) {
+	const id = "12345678-1234-1234-1234-123456789abc"
+
+	monitor := &komodorclient.Monitor{
+		ID:        id,
+		Name:      "foo",
+		Sensors:   []komodorclient.Sensor{{Cluster: "prod", Namespaces: []string{"default"}}},
+		Sinks:     &komodorclient.Sinks{Slack: []string{"alerts", "oncall"}},
+		Active:    true,
+		Type:      "availability",
+		Variables: &komodorclient.Variables{Duration: ptr.To(30), MinAvailable: ptr.To(intstr.FromString("100%"))},
+		SinksOptions: map[string][]string{
+			"notifyOn": {"OOMKilled"},
+			"mentions": {"@oncall"},
+		},
+	}
+	cr := func(policies ...xpv1.ManagementAction) *v1beta1.RealtimeMonitor {
+		return &v1beta1.RealtimeMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{meta.AnnotationKeyExternalName: id},
+			},
+			Spec: v1beta1.RealtimeMonitorSpec{
+				ResourceSpec: xpv1.ResourceSpec{ManagementPolicies: policies},
+				ForProvider: v1beta1.RealtimeMonitorParameters{
+					Name:                 "foo",
+					Sensors:              []v1beta1.Sensor{{Cluster: "prod"}},
+					Sinks:                v1beta1.Sinks{Slack: []string{"alerts"}},
+					NotificationChannels: []string{"oncall"},
+					Active:               true,
+					MonitorTypeParameters: v1beta1.MonitorTypeParameters{
+						Availability: &v1beta1.AvailabilityMonitor{Duration: ptr.To(30)},
+					},
+				},
+			},
+		}
+	}
+	initialized := cr().Spec.ForProvider
+	initialized.Sensors[0].Namespaces = []string{"default"}
+	initialized.Availability.MinAvailable = ptr.To(intstr.FromString("100%"))
+	initialized.SinksOptions = map[string][]string{"mentions": {"@oncall"}}
+
+	channel := func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		obj.(*v1beta1.NotificationChannel).Spec.ForProvider = v1beta1.NotificationChannelParameters{
+			Sinks:        v1beta1.Sinks{Slack: []string{"oncall"}},
+			SinksOptions: map[string][]string{"notifyOn": {"OOMKilled"}},
+		}
+		return nil
+	}
+
+	type want struct {
+		o      managed.ExternalObservation
+		params v1beta1.RealtimeMonitorParameters
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *v1beta1.RealtimeMonitor
+		want   want
+	}{
+		"LateInitialized": {
+			reason: "Fields the spec leaves unset should be filled from Komodor, except sink options taken from notification channels.",
+			mg:     cr(),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				params: initialized,
+			},
+		},
+		"LateInitializePolicy": {
+			reason: "Management policies that include LateInitialize should late initialize the spec.",
+			mg:     cr(xpv1.ManagementActionObserve, xpv1.ManagementActionLateInitialize),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				params: initialized,
+			},
+		},
+		"LateInitializeExcluded": {
+			reason: "Management policies that exclude LateInitialize should leave the spec as it is.",
+			mg:     cr(xpv1.ManagementActionObserve, xpv1.ManagementActionCreate, xpv1.ManagementActionUpdate, xpv1.ManagementActionDelete),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					Diff:             "sensors[prod], sinksOptions.mentions",
+				},
+				params: cr().Spec.ForProvider,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client:   &mockClient{getMonitorFn: func(context.Context, string) (*komodorclient.Monitor, error) { return monitor, nil }},
+				kube:     &test.MockClient{MockGet: channel},
+				recorder: event.NewNopRecorder(),
+			}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.params, tc.mg.Spec.ForProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want forProvider, +got forProvider:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	const id = "12345678-1234-1234-1234-123456789abc"
+	errBoom := errors.New("boom")
+
+	cr := func(mode v1beta1.DeletionMode, suffix string) *v1beta1.RealtimeMonitor {
+		return &v1beta1.RealtimeMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{meta.AnnotationKeyExternalName: id},
+			},
+			Spec: v1beta1.RealtimeMonitorSpec{DeletionMode: mode, DeactivatedNameSuffix: suffix},
+		}
+	}
+	monitor := func(name string, active bool) func(context.Context, string) (*komodorclient.Monitor, error) {
+		return func(context.Context, string) (*komodorclient.Monitor, error) {
+			return &komodorclient.Monitor{
+				ID:        id,
+				CreatedAt: "2025-01-01T00:00:00Z",
+				Name:      name,
+				Active:    active,
+				Type:      "deploy",
+				Sensors:   []komodorclient.Sensor{{Cluster: "prod"}},
+			}, nil
+		}
+	}
+
+	type want struct {
+		o       managed.ExternalDelete
+		deleted []string
+		updated []*komodorclient.Monitor
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		mg        *v1beta1.RealtimeMonitor
+		get       func(context.Context, string) (*komodorclient.Monitor, error)
+		deleteErr error
+		want      want
+	}{
+		"Delete": {
+			reason: "The Delete mode should delete the monitor.",
+			mg:     cr(v1beta1.DeletionModeDelete, ""),
+			want:   want{deleted: []string{id}},
+		},
+		"DeleteNotFound": {
+			reason:    "A monitor already deleted in Komodor should count as deleted.",
+			mg:        cr("", ""),
+			deleteErr: &komodorclient.APIError{StatusCode: 404},
+			want:      want{deleted: []string{id}},
+		},
+		"Deactivate": {
+			reason: "The Deactivate mode should set the monitor inactive and append the name suffix, keeping its other fields.",
+			mg:     cr(v1beta1.DeletionModeDeactivate, " (orphaned)"),
+			get:    monitor("checkout", true),
+			want: want{
+				o:       managed.ExternalDelete{AdditionalDetails: managed.AdditionalDetails{"deletionMode": "Deactivate"}},
+				updated: []*komodorclient.Monitor{{Name: "checkout (orphaned)", Type: "deploy", Sensors: []komodorclient.Sensor{{Cluster: "prod"}}}},
+			},
+		},
+		"DeactivateRenamed": {
+			reason: "A monitor already carrying the name suffix should not be renamed again.",
+			mg:     cr(v1beta1.DeletionModeDeactivate, " (orphaned)"),
+			get:    monitor("checkout (orphaned)", true),
+			want: want{
+				o:       managed.ExternalDelete{AdditionalDetails: managed.AdditionalDetails{"deletionMode": "Deactivate"}},
+				updated: []*komodorclient.Monitor{{Name: "checkout (orphaned)", Type: "deploy", Sensors: []komodorclient.Sensor{{Cluster: "prod"}}}},
+			},
+		},
+		"DeactivateGetError": {
+			reason: "An error getting the monitor to deactivate should be returned.",
+			mg:     cr(v1beta1.DeletionModeDeactivate, ""),
+			get: func(context.Context, string) (*komodorclient.Monitor, error) {
+				return nil, errBoom
+			},
+			want: want{err: errors.Wrap(errBoom, "cannot get monitor from Komodor")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got want
+			e := external{client: &mockClient{
+				getMonitorFn: tc.get,
+				updateMonitorFn: func(_ context.Context, _ string, m *komodorclient.Monitor) (*komodorclient.Monitor, error) {
+					got.updated = append(got.updated, m)
+					return m, nil
+				},
+				deleteMonitorFn: func(_ context.Context, id string) error {
+					got.deleted = append(got.deleted, id)
+					return tc.deleteErr
+				},
+			}}
+			got.o, got.err = e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors(), cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMonitorsForChannel(t *testing.T) {
+	monitors := []v1beta1.RealtimeMonitor{
+		{ObjectMeta: metav1.ObjectMeta{Name: "resolved"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{NotificationChannels: []string{"oncall"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "referenced"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{SinkRefs: []xpv1.Reference{{Name: "oncall"}}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "selecting"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{SinkSelector: &xpv1.Selector{}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{NotificationChannels: []string{"team"}}}},
+	}
+	kube := &test.MockClient{MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		obj.(*v1beta1.RealtimeMonitorList).Items = monitors
+		return nil
+	}}
+
+	got := monitorsForChannel(kube, logging.NewNopLogger())(context.Background(), &v1beta1.NotificationChannel{ObjectMeta: metav1.ObjectMeta{Name: "oncall"}})
+	want := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "resolved"}},
+		{NamespacedName: types.NamespacedName{Name: "referenced"}},
+		{NamespacedName: types.NamespacedName{Name: "selecting"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("monitorsForChannel(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestMonitorsForSinkSecret(t *testing.T) {
+	ref := func(name string) xpv1.SecretKeySelector {
+		return xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "ns", Name: name}, Key: "value"}
+	}
+	channels := []v1beta1.NotificationChannel{
+		{ObjectMeta: metav1.ObjectMeta{Name: "oncall"}, Spec: v1beta1.NotificationChannelSpec{ForProvider: v1beta1.NotificationChannelParameters{Sinks: v1beta1.Sinks{SlackSecretRefs: []xpv1.SecretKeySelector{ref("sinks")}}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team"}},
+	}
+	pagerDuty := ref("sinks")
+	monitors := []v1beta1.RealtimeMonitor{
+		{ObjectMeta: metav1.ObjectMeta{Name: "webhook"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{Sinks: v1beta1.Sinks{GenericWebhookSecretRefs: []xpv1.SecretKeySelector{ref("sinks")}}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pagerduty"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{Sinks: v1beta1.Sinks{PagerDuty: []v1beta1.PagerDutySink{{Channel: "oncall", IntegrationKeySecretRef: &pagerDuty}}}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "channel"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{SinkRefs: []xpv1.Reference{{Name: "oncall"}}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "selecting"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{SinkSelector: &xpv1.Selector{}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other-channel"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{NotificationChannels: []string{"team"}}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other-secret"}, Spec: v1beta1.RealtimeMonitorSpec{ForProvider: v1beta1.RealtimeMonitorParameters{Sinks: v1beta1.Sinks{TeamsSecretRefs: []xpv1.SecretKeySelector{ref("other")}}}}},
+	}
+	kube := &test.MockClient{MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		switch l := obj.(type) {
+		case *v1beta1.NotificationChannelList:
+			l.Items = channels
+		case *v1beta1.RealtimeMonitorList:
+			l.Items = monitors
+		}
+		return nil
+	}}
+
+	cases := map[string]struct {
+		reason string
+		secret string
+		want   []reconcile.Request
+	}{
+		"SinkSecret": {
+			reason: "A Secret sinks read from should enqueue the RealtimeMonitors reading it themselves or through a NotificationChannel.",
+			secret: "sinks",
+			want: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "webhook"}},
+				{NamespacedName: types.NamespacedName{Name: "pagerduty"}},
+				{NamespacedName: types.NamespacedName{Name: "channel"}},
+				{NamespacedName: types.NamespacedName{Name: "selecting"}},
+			},
+		},
+		"OtherSecret": {
+			reason: "A Secret no NotificationChannel reads from should only enqueue the RealtimeMonitors reading it themselves.",
+			secret: "other",
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "other-secret"}}},
+		},
+		"Unreferenced": {
+			reason: "A Secret no sink reads from should enqueue nothing.",
+			secret: "unrelated",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: tc.secret}}
+			got := monitorsForSinkSecret(kube, logging.NewNopLogger())(context.Background(), s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nmonitorsForSinkSecret(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMonitorsForCredentials(t *testing.T) {
+	secretRef := func(name string) apisv1alpha1.ProviderCredentials {
+		return apisv1alpha1.ProviderCredentials{
+			Source: xpv1.CredentialsSourceSecret,
+			CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+				SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: name},
+				Key:             "apiKey",
+			}},
+		}
+	}
+	rotating := secretRef("komodor-next")
+	pcs := []apisv1alpha1.ProviderConfig{
+		{ObjectMeta: metav1.ObjectMeta{Name: "primary", UID: "primary-uid"}, Spec: apisv1alpha1.ProviderConfigSpec{Credentials: secretRef("komodor")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "secondary", UID: "secondary-uid"}, Spec: apisv1alpha1.ProviderConfigSpec{Credentials: secretRef("old"), SecondaryCredentials: &rotating}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other", UID: "other-uid"}, Spec: apisv1alpha1.ProviderConfigSpec{Credentials: secretRef("other")}},
+	}
+	monitor := func(name, pc string) v1beta1.RealtimeMonitor {
+		m := v1beta1.RealtimeMonitor{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		m.SetProviderConfigReference(&xpv1.Reference{Name: pc})
+		return m
+	}
+	monitors := []v1beta1.RealtimeMonitor{monitor("a", "primary"), monitor("b", "secondary"), monitor("c", "other")}
+	kube := &test.MockClient{MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		switch l := obj.(type) {
+		case *apisv1alpha1.ProviderConfigList:
+			l.Items = pcs
+		case *v1beta1.RealtimeMonitorList:
+			l.Items = monitors
+		}
+		return nil
+	}}
+
+	cases := map[string]struct {
+		reason string
+		secret string
+		want   []reconcile.Request
+	}{
+		"Credentials": {
+			reason: "A Secret a ProviderConfig reads its credentials from should enqueue its RealtimeMonitors.",
+			secret: "komodor",
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "a"}}},
+		},
+		"SecondaryCredentials": {
+			reason: "A Secret a ProviderConfig reads its secondary credentials from should enqueue its RealtimeMonitors.",
+			secret: "komodor-next",
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "b"}}},
+		},
+		"Unreferenced": {
+			reason: "A Secret no ProviderConfig reads credentials from should enqueue nothing.",
+			secret: "unrelated",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: tc.secret}}
+			got := monitorsForCredentials(kube, logging.NewNopLogger())(context.Background(), s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nmonitorsForCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package realtimemonitor
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+)
+
+// sinkSecrets are the resolved values of a spec's sink secret references.
+// They are only ever sent to Komodor, never written to the managed resource,
+// so they stay out of its status and of the change logs built from it.
+type sinkSecrets struct {
+	slack          []string
+	teams          []string
+	opsgenie       []string
+	genericWebhook []string
+
+	// pagerDuty maps the index of a spec PagerDuty sink to its integration
+	// key.
+	pagerDuty map[int]string
+
+	// key keys the hash of the values, so the hash in the status of one
+	// monitor cannot be matched against guessed values or the hashes of
+	// other monitors.
+	key []byte
+}
+
+// Helper: Read the value a SecretKeySelector selects
+func (c *external) getSecretValue(ctx context.Context, ref xpv1.SecretKeySelector) (string, error) {
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", errors.Wrapf(err, "cannot get secret %s/%s", ref.Namespace, ref.Name)
+	}
+	v, ok := s.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("secret %s/%s has no key %q", ref.Namespace, ref.Name, ref.Key)
+	}
+	return string(v), nil
+}
+
+// Helper: Read the values a list of SecretKeySelectors select
+func (c *external) getSecretValues(ctx context.Context, refs []xpv1.SecretKeySelector) ([]string, error) {
+	var out []string
+	for _, ref := range refs {
+		v, err := c.getSecretValue(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// Helper: Resolve the secret references of the supplied sinks. Their hash is
+// keyed by the supplied key.
+func (c *external) resolveSinkSecrets(ctx context.Context, sinks *v1beta1.Sinks, key []byte) (*sinkSecrets, error) {
+	s := &sinkSecrets{pagerDuty: map[int]string{}, key: key}
+	var err error
+	if s.slack, err = c.getSecretValues(ctx, sinks.SlackSecretRefs); err != nil {
+		return nil, errors.Wrap(err, "cannot resolve slack sink secrets")
+	}
+	if s.teams, err = c.getSecretValues(ctx, sinks.TeamsSecretRefs); err != nil {
+		return nil, errors.Wrap(err, "cannot resolve teams sink secrets")
+	}
+	if s.opsgenie, err = c.getSecretValues(ctx, sinks.OpsgenieSecretRefs); err != nil {
+		return nil, errors.Wrap(err, "cannot resolve opsgenie sink secrets")
+	}
+	if s.genericWebhook, err = c.getSecretValues(ctx, sinks.GenericWebhookSecretRefs); err != nil {
+		return nil, errors.Wrap(err, "cannot resolve genericWebhook sink secrets")
+	}
+	for i, pd := range sinks.PagerDuty {
+		if pd.IntegrationKeySecretRef == nil {
+			continue
+		}
+		v, err := c.getSecretValue(ctx, *pd.IntegrationKeySecretRef)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot resolve integration key of pagerDuty sink %q", pd.Channel)
+		}
+		s.pagerDuty[i] = v
+	}
+	return s, nil
+}
+
+// Helper: Add the secret sink values to sinks built from a spec
+func (s *sinkSecrets) apply(out *komodorclient.Sinks) {
+	out.Slack = append(slices.Clone(out.Slack), s.slack...)
+	out.Teams = append(slices.Clone(out.Teams), s.teams...)
+	out.Opsgenie = append(slices.Clone(out.Opsgenie), s.opsgenie...)
+	out.GenericWebhook = append(slices.Clone(out.GenericWebhook), s.genericWebhook...)
+	for i, key := range s.pagerDuty {
+		out.PagerDuty[i].IntegrationKey = key
+	}
+}
+
+// Helper: Remove the secret sink values from sinks observed in Komodor
+func (s *sinkSecrets) redact(in *v1beta1.Sinks) *v1beta1.Sinks {
+	if in == nil {
+		return nil
+	}
+	secret := map[string]bool{}
+	for _, v := range slices.Concat(s.slack, s.teams, s.opsgenie, s.genericWebhook) {
+		secret[v] = true
+	}
+	for _, v := range s.pagerDuty {
+		secret[v] = true
+	}
+	without := func(values []string) []string {
+		var out []string
+		for _, v := range values {
+			if !secret[v] {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+
+	out := in.DeepCopy()
+	out.Slack = without(in.Slack)
+	out.Teams = without(in.Teams)
+	out.Opsgenie = without(in.Opsgenie)
+	out.GenericWebhook = without(in.GenericWebhook)
+	for i := range out.PagerDuty {
+		if secret[out.PagerDuty[i].IntegrationKey] {
+			out.PagerDuty[i].IntegrationKey = ""
+		}
+	}
+	return out
+}
+
+// Helper: Hash the secret sink values with an HMAC, an empty string when
+// there are none. The hash lets observations detect rotated secrets without
+// keeping the values themselves.
+func (s *sinkSecrets) hash() string {
+	if len(s.slack)+len(s.teams)+len(s.opsgenie)+len(s.genericWebhook)+len(s.pagerDuty) == 0 {
+		return ""
+	}
+	h := hmac.New(sha256.New, s.key)
+	write := func(kind string, values ...string) {
+		for _, v := range values {
+			h.Write([]byte(kind))
+			h.Write([]byte{0})
+			h.Write([]byte(v))
+			h.Write([]byte{0})
+		}
+	}
+	write("slack", s.slack...)
+	write("teams", s.teams...)
+	write("opsgenie", s.opsgenie...)
+	write("genericWebhook", s.genericWebhook...)
+	indexes := make([]int, 0, len(s.pagerDuty))
+	for i := range s.pagerDuty {
+		indexes = append(indexes, i)
+	}
+	slices.Sort(indexes)
+	for _, i := range indexes {
+		write("pagerDuty/"+strconv.Itoa(i), s.pagerDuty[i])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Helper: Whether the supplied sinks read a secret from the Secret with the
+// supplied namespace and name
+func readsSecret(sinks *v1beta1.Sinks, namespace, name string) bool {
+	reads := func(ref xpv1.SecretKeySelector) bool {
+		return ref.Namespace == namespace && ref.Name == name
+	}
+	for _, refs := range [][]xpv1.SecretKeySelector{sinks.SlackSecretRefs, sinks.TeamsSecretRefs, sinks.OpsgenieSecretRefs, sinks.GenericWebhookSecretRefs} {
+		if slices.ContainsFunc(refs, reads) {
+			return true
+		}
+	}
+	return slices.ContainsFunc(sinks.PagerDuty, func(pd v1beta1.PagerDutySink) bool {
+		return pd.IntegrationKeySecretRef != nil && reads(*pd.IntegrationKeySecretRef)
+	})
+}
+
+// monitorsForSinkSecret maps a Secret to the RealtimeMonitors whose sinks, or
+// the sinks of the NotificationChannels they reference or may select, read
+// from it, so rotated sink secrets are sent to Komodor without waiting for
+// the next poll.
+func monitorsForSinkSecret(kube client.Reader, log logging.Logger) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		ncs := &v1beta1.NotificationChannelList{}
+		if err := kube.List(ctx, ncs); err != nil {
+			log.Info("Cannot list NotificationChannels reading sink secrets from Secret", "secret", o.GetNamespace()+"/"+o.GetName(), "error", err)
+			return nil
+		}
+		channels := map[string]bool{}
+		for _, nc := range ncs.Items {
+			if readsSecret(&nc.Spec.ForProvider.Sinks, o.GetNamespace(), o.GetName()) {
+				channels[nc.GetName()] = true
+			}
+		}
+
+		l := &v1beta1.RealtimeMonitorList{}
+		if err := kube.List(ctx, l); err != nil {
+			log.Info("Cannot list RealtimeMonitors reading sink secrets from Secret", "secret", o.GetNamespace()+"/"+o.GetName(), "error", err)
+			return nil
+		}
+
+		var reqs []reconcile.Request
+		for _, m := range l.Items {
+			p := m.Spec.ForProvider
+			reads := readsSecret(&p.Sinks, o.GetNamespace(), o.GetName()) ||
+				slices.ContainsFunc(p.NotificationChannels, func(n string) bool { return channels[n] }) ||
+				slices.ContainsFunc(p.SinkRefs, func(r xpv1.Reference) bool { return channels[r.Name] }) ||
+				(len(channels) > 0 && p.SinkSelector != nil && len(p.NotificationChannels) == 0)
+			if reads {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: m.GetName()}})
+			}
+		}
+		return reqs
+	}
+}
@@ -18,6 +18,7 @@ package realtimemonitor
 
 import (
 	"context"
+	"strings"
 
 	meta "github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/pkg/errors"
@@ -25,12 +26,20 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
-	"github.com/crossplane/provider-komodor/apis/komodor/v1alpha1"
-	komodorclient "github.com/crossplane/provider-komodor/internal/clients/komodor"
+	"github.com/crossplane/provider-komodor/apis/komodor/v1beta1"
+	"github.com/crossplane/provider-komodor/internal/tracing"
 )
 
+// Update updates the RealtimeMonitor in Komodor within a span.
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	cr, ok := mg.(*v1alpha1.RealtimeMonitor)
+	ctx, span := startSpan(ctx, "Update", mg)
+	upd, err := c.update(ctx, mg)
+	tracing.End(span, err)
+	return upd, err
+}
+
+func (c *external) update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.RealtimeMonitor)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotRealtimeMonitor)
 	}
@@ -41,38 +50,24 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New("external name (monitor ID) is not set")
 	}
 
-	sensors, err := unmarshalSensors(cr.Spec.ForProvider.Sensors)
-	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to unmarshal spec sensors")
-	}
-	sinks, err := unmarshalMap(cr.Spec.ForProvider.Sinks)
-	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to unmarshal spec sinks")
-	}
-	variables, err := unmarshalMap(cr.Spec.ForProvider.Variables)
+	desired, secrets, err := c.resolveDesired(ctx, cr)
 	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, "failed to unmarshal spec variables")
-	}
-
-	monitor := &komodorclient.Monitor{
-		Name:         cr.Spec.ForProvider.Name,
-		Sensors:      sensors,
-		Sinks:        sinks,
-		Active:       cr.Spec.ForProvider.Active,
-		Type:         cr.Spec.ForProvider.Type,
-		Variables:    variables,
-		SinksOptions: cr.Spec.ForProvider.SinksOptions,
+		return managed.ExternalUpdate{}, err
 	}
 
-	updated, err := c.client.UpdateMonitor(ctx, monitorID, monitor)
+	updated, err := c.client.UpdateMonitor(ctx, monitorID, generateMonitor(desired, secrets))
 	if err != nil {
 		cr.SetConditions(xpv1.ReconcileError(errors.Wrap(err, "cannot update monitor in Komodor")))
 		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot update monitor in Komodor")
 	}
 
-	if err := updateStatusFromMonitor(cr, updated); err != nil {
-		return managed.ExternalUpdate{}, err
-	}
+	updateStatusFromMonitor(cr, updated, secrets)
+	cr.Status.AtProvider.SinkSecretsHash = secrets.hash()
 
-	return managed.ExternalUpdate{}, nil
+	// Change logs record what the update corrected.
+	var details managed.AdditionalDetails
+	if drift := cr.Status.AtProvider.DriftedFields; len(drift) > 0 {
+		details = managed.AdditionalDetails{"driftedFields": strings.Join(drift, ", ")}
+	}
+	return managed.ExternalUpdate{AdditionalDetails: details}, nil
 }
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		o       Options
+		wantErr bool
+	}{
+		"Disabled": {
+			reason: "Tracing should be disabled without an exporter.",
+			o:      Options{Exporter: ExporterNone},
+		},
+		"UnknownExporter": {
+			reason:  "An unknown exporter should be rejected.",
+			o:       Options{Exporter: "jaeger"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), tc.o)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("\n%s\nSetup(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if err == nil {
+				if err := shutdown(context.Background()); err != nil {
+					t.Errorf("\n%s\nshutdown(...): unexpected error: %v", tc.reason, err)
+				}
+			}
+		})
+	}
+}
+
+func TestEnd(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   codes.Code
+	}{
+		"Succeeded": {
+			reason: "A span without an error should keep an unset status.",
+			want:   codes.Unset,
+		},
+		"Failed": {
+			reason: "A span with an error should record it and fail.",
+			err:    errBoom,
+			want:   codes.Error,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := tracetest.NewSpanRecorder()
+			_, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer("test").Start(context.Background(), "op")
+			End(span, tc.err)
+
+			ended := rec.Ended()
+			if len(ended) != 1 {
+				t.Fatalf("\n%s\nEnd(...): want 1 ended span, got %d", tc.reason, len(ended))
+			}
+			if got := ended[0].Status().Code; got != tc.want {
+				t.Errorf("\n%s\nEnd(...): want status %v, got %v", tc.reason, tc.want, got)
+			}
+		})
+	}
+}